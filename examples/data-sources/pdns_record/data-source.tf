@@ -0,0 +1,13 @@
+data "pdns_record" "lb" {
+  zone = "example.com."
+  name = "lb"
+  type = "A"
+}
+
+resource "pdns_record" "app" {
+  zone = "example.com."
+  name = "app"
+  type = "A"
+
+  records = data.pdns_record.lb.contents
+}
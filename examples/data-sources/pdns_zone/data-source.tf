@@ -0,0 +1,13 @@
+data "pdns_zone" "example_com" {
+  name = "example.com."
+}
+
+data "pdns_zone" "example_com_with_rrsets" {
+  name = "example.com."
+
+  include_rrsets = true
+}
+
+output "example_com_nameservers" {
+  value = data.pdns_zone.example_com.nameservers
+}
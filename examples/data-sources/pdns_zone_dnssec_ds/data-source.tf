@@ -0,0 +1,12 @@
+data "pdns_zone_dnssec_ds" "sub_example_com" {
+  zone = "sub.example.com."
+}
+
+# Delegate the signed child zone from its parent
+resource "pdns_record" "sub_ds" {
+  zone = "example.com."
+  name = "sub"
+  type = "DS"
+
+  records = data.pdns_zone_dnssec_ds.sub_example_com.ds
+}
@@ -0,0 +1,13 @@
+data "pdns_zones" "primaries" {
+  kind       = "Master"
+  name_regex = "\\.example\\.com\\.$"
+}
+
+output "primary_zone_names" {
+  value = data.pdns_zones.primaries.names
+}
+
+# The members of a producer catalog zone
+data "pdns_zones" "catalog_members" {
+  catalog = "catalog.example.com."
+}
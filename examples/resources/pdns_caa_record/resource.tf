@@ -0,0 +1,16 @@
+resource "pdns_caa_record" "example_com" {
+  zone = "example.com."
+  name = "example.com."
+
+  records = [
+    {
+      tag   = "issue"
+      value = "letsencrypt.org"
+    },
+    {
+      flags = 128
+      tag   = "iodef"
+      value = "mailto:security@example.com"
+    },
+  ]
+}
@@ -0,0 +1,2 @@
+# Import by zone and the id PowerDNS assigned to the key
+terraform import pdns_cryptokey.example_com_csk 'example.com.:3'
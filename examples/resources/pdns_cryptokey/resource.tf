@@ -0,0 +1,29 @@
+resource "pdns_zone" "example_com" {
+  name = "example.com."
+
+  dnssec = true
+
+  nameservers = [
+    {
+      hostname = "ns1",
+      address  = "10.10.10.1"
+    }
+  ]
+
+  soa = {
+    rname = "hostmaster"
+  }
+}
+
+resource "pdns_cryptokey" "example_com_csk" {
+  zone = pdns_zone.example_com.name
+
+  key_type  = "csk"
+  algorithm = "ECDSAP256SHA256"
+  active    = true
+}
+
+# The DS records to hand to the registrar of example.com
+output "example_com_ds" {
+  value = pdns_cryptokey.example_com_csk.ds
+}
@@ -0,0 +1,5 @@
+# Import by zone and name
+terraform import pdns_mx_record.example_com 'example.com.:example.com.'
+
+# Import by fully qualified name, the zone is looked up on the server
+terraform import pdns_mx_record.example_com 'example.com.'
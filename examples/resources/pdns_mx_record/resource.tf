@@ -0,0 +1,15 @@
+resource "pdns_mx_record" "example_com" {
+  zone = "example.com."
+  name = "example.com."
+
+  records = [
+    {
+      priority = 10
+      exchange = "mail.example.com."
+    },
+    {
+      priority = 20
+      exchange = "mail2.example.com."
+    },
+  ]
+}
@@ -0,0 +1,5 @@
+# Import by zone, name and type
+terraform import pdns_record.test2_a 'example.com.:test2:A'
+
+# Import by fully qualified name and type, the zone is looked up on the server
+terraform import pdns_record.test2_a 'test2.example.com./A'
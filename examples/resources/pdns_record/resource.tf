@@ -47,3 +47,27 @@ resource "pdns_record" "test2_a" {
   ]
 }
 
+# A round-robin set with one backend taken out of rotation
+resource "pdns_record" "app_a" {
+  zone = pdns_zone.example_com.name
+
+  name = "app"
+
+  type = "A"
+  record = [
+    {
+      content = "10.10.10.5"
+    },
+    {
+      content  = "10.10.10.6"
+      disabled = true
+    },
+  ]
+
+  comment = [
+    {
+      content = "Disabled for maintenance, see OPS-1234"
+      account = "alice"
+    },
+  ]
+}
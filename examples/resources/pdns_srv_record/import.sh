@@ -0,0 +1,5 @@
+# Import by zone and name
+terraform import pdns_srv_record.sip_udp 'example.com.:_sip._udp'
+
+# Import by fully qualified name, the zone is looked up on the server
+terraform import pdns_srv_record.sip_udp '_sip._udp.example.com.'
@@ -0,0 +1,13 @@
+resource "pdns_srv_record" "sip_udp" {
+  zone = "example.com."
+  name = "_sip._udp"
+
+  records = [
+    {
+      priority = 10
+      weight   = 5
+      port     = 5060
+      target   = "sip.example.com."
+    },
+  ]
+}
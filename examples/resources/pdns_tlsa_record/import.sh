@@ -0,0 +1,5 @@
+# Import by zone and name
+terraform import pdns_tlsa_record.www_https 'example.com.:_443._tcp.www'
+
+# Import by fully qualified name, the zone is looked up on the server
+terraform import pdns_tlsa_record.www_https '_443._tcp.www.example.com.'
@@ -0,0 +1,13 @@
+resource "pdns_tlsa_record" "www_https" {
+  zone = "example.com."
+  name = "_443._tcp.www"
+
+  records = [
+    {
+      usage         = 3
+      selector      = 1
+      matching_type = 1
+      data          = "0c72ac70b745ac19998811b131d662c9ac69dbdbe7cb23e5b514b56664c5d3d6"
+    },
+  ]
+}
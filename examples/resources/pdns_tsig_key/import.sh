@@ -0,0 +1,2 @@
+# Import by the id PowerDNS derived from the name of the key
+terraform import pdns_tsig_key.axfr_example_com 'axfr-example-com.'
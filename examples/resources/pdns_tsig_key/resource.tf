@@ -0,0 +1,29 @@
+resource "pdns_tsig_key" "axfr_example_com" {
+  name      = "axfr-example-com"
+  algorithm = "hmac-sha256"
+}
+
+resource "pdns_zone" "example_com" {
+  name = "example.com."
+  kind = "Master"
+
+  # Secondaries must sign their transfer requests with this key
+  master_tsig_key_ids = [pdns_tsig_key.axfr_example_com.key_id]
+
+  nameservers = [
+    {
+      hostname = "ns1",
+      address  = "10.10.10.1"
+    }
+  ]
+
+  soa = {
+    rname = "hostmaster"
+  }
+}
+
+# The generated secret to configure on the secondaries
+output "axfr_example_com_key" {
+  value     = pdns_tsig_key.axfr_example_com.key
+  sensitive = true
+}
@@ -0,0 +1,13 @@
+resource "pdns_txt_record" "example_com" {
+  zone = "example.com."
+  name = "example.com."
+
+  records = [
+    {
+      value = "v=spf1 mx -all"
+    },
+    {
+      value = "google-site-verification=abcdefghijklmnopqrstuvwxyz"
+    },
+  ]
+}
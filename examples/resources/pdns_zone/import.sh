@@ -0,0 +1,2 @@
+# Import by zone name, nameservers and SOA are reconstructed from the server
+terraform import pdns_zone.example_com 'example.com.'
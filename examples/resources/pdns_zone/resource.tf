@@ -3,6 +3,9 @@ resource "pdns_zone" "example_com" {
 
   dnssec = false
 
+  # PowerDNS increases the serial on every change, including pdns_record ones
+  soa_edit_api = "DEFAULT"
+
   nameservers = [
     {
       hostname = "ns1",
@@ -25,3 +28,44 @@ resource "pdns_zone" "example_com" {
 
 }
 
+# A secondary zone transferred from another primary. SOA and nameservers are
+# part of the transferred data and must not be set.
+resource "pdns_zone" "example_org" {
+  name = "example.org."
+  kind = "Slave"
+
+  masters = ["192.0.2.1", "[2001:db8::1]:5300"]
+}
+
+# A producer catalog zone and a member zone. Secondaries consuming the catalog
+# provision example.net. automatically.
+resource "pdns_zone" "catalog" {
+  name = "catalog.example.com."
+  kind = "Producer"
+
+  nameservers = [
+    {
+      hostname = "invalid."
+    }
+  ]
+
+  soa = {
+    rname = "hostmaster.example.com."
+  }
+}
+
+resource "pdns_zone" "example_net" {
+  name    = "example.net."
+  kind    = "Master"
+  catalog = pdns_zone.catalog.name
+
+  nameservers = [
+    {
+      hostname = "ns1.example.com."
+    }
+  ]
+
+  soa = {
+    rname = "hostmaster"
+  }
+}
@@ -0,0 +1,2 @@
+# Import by zone and metadata kind, separated by a colon
+terraform import pdns_zone_metadata.example_com_allow_axfr 'example.com.:ALLOW-AXFR-FROM'
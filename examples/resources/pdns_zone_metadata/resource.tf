@@ -0,0 +1,29 @@
+resource "pdns_zone" "example_com" {
+  name = "example.com."
+  kind = "Master"
+
+  nameservers = [
+    {
+      hostname = "ns1",
+      address  = "10.10.10.1"
+    }
+  ]
+
+  soa = {
+    rname = "hostmaster"
+  }
+}
+
+# Allow the secondaries to transfer the zone
+resource "pdns_zone_metadata" "example_com_allow_axfr" {
+  zone   = pdns_zone.example_com.name
+  kind   = "ALLOW-AXFR-FROM"
+  values = ["10.10.20.0/24", "AUTO-NS"]
+}
+
+# Custom metadata, ignored by PowerDNS itself
+resource "pdns_zone_metadata" "example_com_owner" {
+  zone   = pdns_zone.example_com.name
+  kind   = "X-OWNER"
+  values = ["team-dns"]
+}
@@ -0,0 +1,44 @@
+// Package acctest wires the provider to an in-memory pdnstest.Server so that
+// resource lifecycles can be exercised offline by acceptance tests. Driver runs
+// them without a Terraform binary; the factories and configuration helpers
+// serve the same purpose for terraform-plugin-testing.
+package acctest
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdnstest"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/provider"
+)
+
+// ProviderName is the local name under which the provider is registered.
+const ProviderName = "pdns"
+
+// ProtoV6ProviderFactories returns provider factories that serve the provider
+// in-process via providerserver, in the shape expected by
+// terraform-plugin-testing's resource.TestCase.
+func ProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		ProviderName: providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+}
+
+// ProviderConfig returns a provider block pointing at server. Prepend it to
+// every test step configuration.
+func ProviderConfig(server *pdnstest.Server) string {
+	return fmt.Sprintf(`
+provider %q {
+  endpoint  = %q
+  api_key   = %q
+  server_id = %q
+}
+`, ProviderName, server.URL, server.APIKey, server.ServerID)
+}
+
+// Config concatenates the provider block for server with the given resource
+// configuration.
+func Config(server *pdnstest.Server, config string) string {
+	return ProviderConfig(server) + config
+}
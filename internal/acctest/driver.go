@@ -0,0 +1,476 @@
+package acctest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdnstest"
+)
+
+// Driver plays the part of Terraform core in tests: it serves the provider
+// in-process against a fresh pdnstest.Server and runs the validate, plan,
+// apply, read and import calls Terraform would make for a resource, so that
+// lifecycles can be tested without a Terraform binary.
+//
+// Configurations are given as plain Go values: strings, bools, ints and
+// float64s for primitives, []any for lists and sets and map[string]any for
+// objects and maps. Attributes that are left out are null.
+type Driver struct {
+	Server *pdnstest.Server
+
+	t        testing.TB
+	provider tfprotov6.ProviderServer
+	schema   *tfprotov6.GetProviderSchemaResponse
+}
+
+// NewDriver starts a fake server and configures the provider against it. Both
+// are cleaned up with t. providerConfig adds provider arguments besides
+// endpoint, api_key and server_id.
+func NewDriver(t testing.TB, providerConfig map[string]any) *Driver {
+	t.Helper()
+
+	server := pdnstest.NewServer()
+	t.Cleanup(server.Close)
+
+	provider, err := ProtoV6ProviderFactories()[ProviderName]()
+	if err != nil {
+		t.Fatalf("creating provider: %s", err)
+	}
+
+	ctx := context.Background()
+	schema, err := provider.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err == nil {
+		err = diagnosticsError(schema.Diagnostics)
+	}
+	if err != nil {
+		t.Fatalf("reading provider schema: %s", err)
+	}
+
+	d := &Driver{Server: server, t: t, provider: provider, schema: schema}
+
+	values := map[string]any{"endpoint": server.URL, "api_key": server.APIKey, "server_id": server.ServerID}
+	for key, value := range providerConfig {
+		values[key] = value
+	}
+	config, err := d.dynamicValue(schema.Provider.ValueType(), values)
+	if err != nil {
+		t.Fatalf("encoding provider config: %s", err)
+	}
+
+	resp, err := provider.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: config})
+	if err == nil {
+		err = diagnosticsError(resp.Diagnostics)
+	}
+	if err != nil {
+		t.Fatalf("configuring provider: %s", err)
+	}
+
+	return d
+}
+
+// Create validates config, plans the creation of the resource and applies it.
+// It returns the new state.
+func (d *Driver) Create(resourceType string, config map[string]any) (tftypes.Value, error) {
+	return d.apply(resourceType, d.null(resourceType), config)
+}
+
+// Update validates config, plans the change of prior to it and applies it. It
+// returns the new state.
+func (d *Driver) Update(resourceType string, prior tftypes.Value, config map[string]any) (tftypes.Value, error) {
+	return d.apply(resourceType, prior, config)
+}
+
+// Plan plans the change of prior to config and returns the planned state. An
+// unchanged resource is planned with a state equal to prior.
+func (d *Driver) Plan(resourceType string, prior tftypes.Value, config map[string]any) (tftypes.Value, error) {
+	planned, _, err := d.plan(resourceType, prior, config)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	return planned.Unmarshal(d.resourceType(resourceType))
+}
+
+// Read refreshes state. It returns a null value if the provider removed the
+// resource from state.
+func (d *Driver) Read(resourceType string, state tftypes.Value) (tftypes.Value, error) {
+	typ := d.resourceType(resourceType)
+	current, err := tfprotov6.NewDynamicValue(typ, state)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	resp, err := d.provider.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{TypeName: resourceType, CurrentState: &current})
+	if err == nil {
+		err = diagnosticsError(resp.Diagnostics)
+	}
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	if resp.NewState == nil {
+		return tftypes.NewValue(typ, nil), nil
+	}
+	return resp.NewState.Unmarshal(typ)
+}
+
+// Import imports the resource with id and reads it, like `terraform import`.
+func (d *Driver) Import(resourceType string, id string) (tftypes.Value, error) {
+	resp, err := d.provider.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{TypeName: resourceType, ID: id})
+	if err == nil {
+		err = diagnosticsError(resp.Diagnostics)
+	}
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	if len(resp.ImportedResources) != 1 {
+		return tftypes.Value{}, fmt.Errorf("expected one imported resource, got %d", len(resp.ImportedResources))
+	}
+
+	state, err := resp.ImportedResources[0].State.Unmarshal(d.resourceType(resourceType))
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	return d.Read(resourceType, state)
+}
+
+// Destroy plans and applies the deletion of the resource in state.
+func (d *Driver) Destroy(resourceType string, state tftypes.Value) error {
+	typ := d.resourceType(resourceType)
+	prior, err := tfprotov6.NewDynamicValue(typ, state)
+	if err != nil {
+		return err
+	}
+	null, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.provider.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{TypeName: resourceType, PriorState: &prior, PlannedState: &null, Config: &null})
+	if err == nil {
+		err = diagnosticsError(resp.Diagnostics)
+	}
+	return err
+}
+
+// ReadDataSource validates config and reads the data source.
+func (d *Driver) ReadDataSource(dataSourceType string, config map[string]any) (tftypes.Value, error) {
+	schema, ok := d.schema.DataSourceSchemas[dataSourceType]
+	if !ok {
+		return tftypes.Value{}, fmt.Errorf("unknown data source type %q", dataSourceType)
+	}
+	typ := schema.ValueType()
+
+	value, err := d.dynamicValue(typ, config)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	validated, err := d.provider.ValidateDataResourceConfig(context.Background(), &tfprotov6.ValidateDataResourceConfigRequest{TypeName: dataSourceType, Config: value})
+	if err == nil {
+		err = diagnosticsError(validated.Diagnostics)
+	}
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	resp, err := d.provider.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{TypeName: dataSourceType, Config: value})
+	if err == nil {
+		err = diagnosticsError(resp.Diagnostics)
+	}
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	return resp.State.Unmarshal(typ)
+}
+
+// Attribute returns the top level attribute name of state, or a null value if
+// state has no such attribute.
+func Attribute(state tftypes.Value, name string) tftypes.Value {
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		return tftypes.Value{}
+	}
+	return attributes[name]
+}
+
+// StringAttribute returns the top level string attribute name of state, or an
+// empty string if it is null or not a string.
+func StringAttribute(state tftypes.Value, name string) string {
+	var value string
+	_ = Attribute(state, name).As(&value)
+	return value
+}
+
+// Int64Attribute returns the top level number attribute name of state, or 0
+// if it is null or not a number.
+func Int64Attribute(state tftypes.Value, name string) int64 {
+	var value big.Float
+	if err := Attribute(state, name).As(&value); err != nil {
+		return 0
+	}
+	number, _ := value.Int64()
+	return number
+}
+
+// ListAttribute returns the elements of the top level list or set attribute
+// name of state, or nil if it is null.
+func ListAttribute(state tftypes.Value, name string) []tftypes.Value {
+	attribute := Attribute(state, name)
+	if attribute.IsNull() {
+		return nil
+	}
+	var elements []tftypes.Value
+	if err := attribute.As(&elements); err != nil {
+		return nil
+	}
+	return elements
+}
+
+// StringsAttribute returns the elements of the top level list or set of
+// strings name of state, or nil if it is null.
+func StringsAttribute(state tftypes.Value, name string) []string {
+	elements := ListAttribute(state, name)
+	if elements == nil {
+		return nil
+	}
+	values := make([]string, len(elements))
+	for i, element := range elements {
+		_ = element.As(&values[i])
+	}
+	return values
+}
+
+func (d *Driver) apply(resourceType string, prior tftypes.Value, config map[string]any) (tftypes.Value, error) {
+	typ := d.resourceType(resourceType)
+
+	planned, value, err := d.plan(resourceType, prior, config)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	priorValue, err := tfprotov6.NewDynamicValue(typ, prior)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	resp, err := d.provider.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{TypeName: resourceType, PriorState: &priorValue, PlannedState: planned, Config: value})
+	if err == nil {
+		err = diagnosticsError(resp.Diagnostics)
+	}
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	return resp.NewState.Unmarshal(typ)
+}
+
+// plan validates config and plans the change of prior to it. It returns the
+// planned state and the encoded config.
+func (d *Driver) plan(resourceType string, prior tftypes.Value, config map[string]any) (*tfprotov6.DynamicValue, *tfprotov6.DynamicValue, error) {
+	ctx := context.Background()
+	typ := d.resourceType(resourceType)
+
+	configValue, err := toValue(typ, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err := tfprotov6.NewDynamicValue(typ, configValue)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validated, err := d.provider.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{TypeName: resourceType, Config: &value})
+	if err == nil {
+		err = diagnosticsError(validated.Diagnostics)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	priorValue, err := tfprotov6.NewDynamicValue(typ, prior)
+	if err != nil {
+		return nil, nil, err
+	}
+	proposed, err := tfprotov6.NewDynamicValue(typ, d.proposedNewState(resourceType, configValue, prior))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := d.provider.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{TypeName: resourceType, PriorState: &priorValue, ProposedNewState: &proposed, Config: &value})
+	if err == nil {
+		err = diagnosticsError(resp.Diagnostics)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.PlannedState, &value, nil
+}
+
+// proposedNewState merges config and prior like Terraform does before
+// planning: computed attributes that are not configured keep their prior
+// value.
+func (d *Driver) proposedNewState(resourceType string, config tftypes.Value, prior tftypes.Value) tftypes.Value {
+	if prior.IsNull() {
+		return config
+	}
+
+	computed := make(map[string]bool)
+	for _, attribute := range d.schema.ResourceSchemas[resourceType].Block.Attributes {
+		computed[attribute.Name] = attribute.Computed
+	}
+
+	var configAttributes, priorAttributes map[string]tftypes.Value
+	_ = config.As(&configAttributes)
+	_ = prior.As(&priorAttributes)
+
+	proposed := make(map[string]tftypes.Value, len(configAttributes))
+	for name, value := range configAttributes {
+		switch {
+		case value.IsNull() && computed[name]:
+			proposed[name] = priorAttributes[name]
+		case value.IsNull():
+			proposed[name] = value
+		default:
+			proposed[name] = mergeObjects(value, priorAttributes[name])
+		}
+	}
+	return tftypes.NewValue(config.Type(), proposed)
+}
+
+// mergeObjects fills null attributes of the nested object config from prior.
+func mergeObjects(config tftypes.Value, prior tftypes.Value) tftypes.Value {
+	objectType, ok := config.Type().(tftypes.Object)
+	if !ok || !config.IsKnown() || !prior.IsKnown() || prior.IsNull() {
+		return config
+	}
+
+	var configAttributes, priorAttributes map[string]tftypes.Value
+	_ = config.As(&configAttributes)
+	_ = prior.As(&priorAttributes)
+
+	merged := make(map[string]tftypes.Value, len(configAttributes))
+	for name := range objectType.AttributeTypes {
+		if configAttributes[name].IsNull() {
+			merged[name] = priorAttributes[name]
+		} else {
+			merged[name] = mergeObjects(configAttributes[name], priorAttributes[name])
+		}
+	}
+	return tftypes.NewValue(objectType, merged)
+}
+
+func (d *Driver) resourceType(resourceType string) tftypes.Type {
+	schema, ok := d.schema.ResourceSchemas[resourceType]
+	if !ok {
+		d.t.Fatalf("unknown resource type %q", resourceType)
+	}
+	return schema.ValueType()
+}
+
+func (d *Driver) null(resourceType string) tftypes.Value {
+	return tftypes.NewValue(d.resourceType(resourceType), nil)
+}
+
+func (d *Driver) dynamicValue(typ tftypes.Type, values map[string]any) (*tfprotov6.DynamicValue, error) {
+	value, err := toValue(typ, values)
+	if err != nil {
+		return nil, err
+	}
+	dynamicValue, err := tfprotov6.NewDynamicValue(typ, value)
+	return &dynamicValue, err
+}
+
+// toValue converts a configuration given as plain Go values into a value of
+// typ.
+func toValue(typ tftypes.Type, value any) (tftypes.Value, error) {
+	if value == nil {
+		return tftypes.NewValue(typ, nil), nil
+	}
+
+	switch typ := typ.(type) {
+	case tftypes.Object:
+		values, ok := value.(map[string]any)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected map[string]any for %s, got %T", typ, value)
+		}
+		attributes := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+		for name, attributeType := range typ.AttributeTypes {
+			attribute, err := toValue(attributeType, values[name])
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("%s: %w", name, err)
+			}
+			attributes[name] = attribute
+		}
+		for name := range values {
+			if _, ok := typ.AttributeTypes[name]; !ok {
+				return tftypes.Value{}, fmt.Errorf("unknown attribute %q", name)
+			}
+		}
+		return tftypes.NewValue(typ, attributes), nil
+	case tftypes.List, tftypes.Set:
+		values, ok := value.([]any)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected []any for %s, got %T", typ, value)
+		}
+		var element tftypes.Type
+		if list, ok := typ.(tftypes.List); ok {
+			element = list.ElementType
+		} else {
+			element = typ.(tftypes.Set).ElementType
+		}
+		elements := make([]tftypes.Value, 0, len(values))
+		for i, item := range values {
+			converted, err := toValue(element, item)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("[%d]: %w", i, err)
+			}
+			elements = append(elements, converted)
+		}
+		return tftypes.NewValue(typ, elements), nil
+	case tftypes.Map:
+		values, ok := value.(map[string]any)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected map[string]any for %s, got %T", typ, value)
+		}
+		elements := make(map[string]tftypes.Value, len(values))
+		for key, item := range values {
+			converted, err := toValue(typ.ElementType, item)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("[%q]: %w", key, err)
+			}
+			elements[key] = converted
+		}
+		return tftypes.NewValue(typ, elements), nil
+	}
+
+	switch value := value.(type) {
+	case string, bool:
+		return tftypes.NewValue(typ, value), nil
+	case int:
+		return tftypes.NewValue(typ, big.NewFloat(float64(value))), nil
+	case int64:
+		return tftypes.NewValue(typ, big.NewFloat(float64(value))), nil
+	case float64:
+		return tftypes.NewValue(typ, big.NewFloat(value)), nil
+	}
+	return tftypes.Value{}, fmt.Errorf("unsupported value %v (%T) for %s", value, value, typ)
+}
+
+// diagnosticsError joins the error diagnostics into an error, or returns nil
+// if there are none.
+func diagnosticsError(diagnostics []*tfprotov6.Diagnostic) error {
+	var errs []error
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity != tfprotov6.DiagnosticSeverityError {
+			continue
+		}
+		if diagnostic.Attribute != nil {
+			errs = append(errs, fmt.Errorf("%s: %s (%s)", diagnostic.Summary, diagnostic.Detail, diagnostic.Attribute))
+		} else {
+			errs = append(errs, fmt.Errorf("%s: %s", diagnostic.Summary, diagnostic.Detail))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,191 @@
+package pdns_client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RrsetBatcher coalesces rrset changes for the same zone that arrive within a
+// short window into a single PATCH request. This avoids one serial bump and one
+// NOTIFY to the secondaries per record when many records are applied at once.
+type RrsetBatcher struct {
+	client *PDNSClient
+	window time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*rrsetBatch
+	// last holds the done channel of the most recent batch per zone, which
+	// the next batch waits for before it is sent.
+	last map[string]chan struct{}
+}
+
+type rrsetBatch struct {
+	changes []*batchedChange
+	keys    map[string]bool
+
+	// previous is closed once the batch before this one for the same zone has
+	// been sent, done once this one has. Batches are thereby sent in the order
+	// they were opened, so a later change to an rrset always wins.
+	previous chan struct{}
+	done     chan struct{}
+}
+
+type batchedChange struct {
+	ctx    context.Context
+	rrsets []Rrset
+	result chan error
+}
+
+// NewRrsetBatcher returns a batcher that sends changes through client. A window
+// of zero disables batching and sends every change on its own.
+func NewRrsetBatcher(client *PDNSClient, window time.Duration) *RrsetBatcher {
+	return &RrsetBatcher{
+		client:  client,
+		window:  window,
+		pending: make(map[string]*rrsetBatch),
+		last:    make(map[string]chan struct{}),
+	}
+}
+
+// UpdateZoneRecords queues rrsets for zoneID and blocks until the PATCH that
+// carries them has completed. The returned error is the one attributable to
+// these rrsets: if a combined request is rejected, every change of the batch is
+// resent on its own so that only the offending ones fail.
+//
+// Cancelling ctx stops the wait, but the change may still be applied as part
+// of its batch.
+func (b *RrsetBatcher) UpdateZoneRecords(ctx context.Context, zoneID string, rrsets []Rrset) error {
+	if b.window <= 0 {
+		return b.client.UpdateZoneRecords(ctx, zoneID, rrsets)
+	}
+
+	change := &batchedChange{ctx: ctx, rrsets: rrsets, result: make(chan error, 1)}
+	b.enqueue(zoneID, change)
+
+	select {
+	case err := <-change.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *RrsetBatcher) enqueue(zoneID string, change *batchedChange) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	batch := b.pending[zoneID]
+
+	// PowerDNS rejects a PATCH that touches the same rrset twice, so a change
+	// for an rrset that is already queued closes the current batch early. It
+	// is still sent before the batch opened for the new change.
+	if batch != nil && batch.conflicts(change) {
+		delete(b.pending, zoneID)
+		go b.flush(zoneID, batch)
+		batch = nil
+	}
+
+	if batch == nil {
+		batch = &rrsetBatch{keys: make(map[string]bool), previous: b.last[zoneID], done: make(chan struct{})}
+		b.pending[zoneID] = batch
+		b.last[zoneID] = batch.done
+		time.AfterFunc(b.window, func() {
+			b.mu.Lock()
+			current := b.pending[zoneID] == batch
+			if current {
+				delete(b.pending, zoneID)
+			}
+			b.mu.Unlock()
+
+			if current {
+				b.flush(zoneID, batch)
+			}
+		})
+	}
+
+	batch.changes = append(batch.changes, change)
+	for _, rrset := range change.rrsets {
+		batch.keys[rrsetKey(rrset)] = true
+	}
+}
+
+func (b *RrsetBatcher) flush(zoneID string, batch *rrsetBatch) {
+	if batch.previous != nil {
+		<-batch.previous
+	}
+	defer func() {
+		close(batch.done)
+
+		b.mu.Lock()
+		if b.last[zoneID] == batch.done {
+			delete(b.last, zoneID)
+		}
+		b.mu.Unlock()
+	}()
+
+	// The batch outlives the request that opened it, so it must not be
+	// cancelled together with that request.
+	ctx := context.WithoutCancel(batch.changes[0].ctx)
+
+	if len(batch.changes) == 1 {
+		change := batch.changes[0]
+		change.result <- b.client.UpdateZoneRecords(change.ctx, zoneID, change.rrsets)
+		return
+	}
+
+	rrsets := make([]Rrset, 0, len(batch.changes))
+	for _, change := range batch.changes {
+		rrsets = append(rrsets, change.rrsets...)
+	}
+
+	tflog.Debug(ctx, "Sending batched rrset changes", map[string]interface{}{
+		"zone":    zoneID,
+		"changes": len(batch.changes),
+		"rrsets":  len(rrsets),
+	})
+
+	err := b.client.UpdateZoneRecords(ctx, zoneID, rrsets)
+	if err == nil || !isBatchRejection(err) {
+		for _, change := range batch.changes {
+			change.result <- err
+		}
+		return
+	}
+
+	tflog.Warn(ctx, "Batched rrset changes were rejected, retrying them one by one", map[string]interface{}{
+		"zone":  zoneID,
+		"error": err.Error(),
+	})
+
+	for _, change := range batch.changes {
+		change.result <- b.client.UpdateZoneRecords(change.ctx, zoneID, change.rrsets)
+	}
+}
+
+func (batch *rrsetBatch) conflicts(change *batchedChange) bool {
+	for _, rrset := range change.rrsets {
+		if batch.keys[rrsetKey(rrset)] {
+			return true
+		}
+	}
+	return false
+}
+
+// isBatchRejection reports whether err may have been caused by a single change
+// of the batch, as opposed to errors that would fail every change alike.
+func isBatchRejection(err error) bool {
+	var apiError *PDNSAPIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+	return apiError.StatusCode == http.StatusBadRequest || apiError.StatusCode == http.StatusUnprocessableEntity
+}
+
+func rrsetKey(rrset Rrset) string {
+	return rrset.Name + "/" + rrset.Type
+}
@@ -0,0 +1,164 @@
+package pdns_client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+// patchTransport delays PATCH requests and records how many were in flight at
+// once, which makes reordered or overlapping batches observable.
+type patchTransport struct {
+	delay       time.Duration
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+	patches     atomic.Int32
+}
+
+func (transport *patchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPatch {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+
+	transport.patches.Add(1)
+	current := transport.inFlight.Add(1)
+	defer transport.inFlight.Add(-1)
+	for {
+		seen := transport.maxInFlight.Load()
+		if current <= seen || transport.maxInFlight.CompareAndSwap(seen, current) {
+			break
+		}
+	}
+
+	time.Sleep(transport.delay)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newTestBatcher(t *testing.T, window time.Duration, delay time.Duration) (*pdns_client.RrsetBatcher, *patchTransport, func(name, rrType string) []string) {
+	t.Helper()
+
+	_, server := newTestClient(t)
+	transport := &patchTransport{delay: delay}
+	client := pdns_client.NewPDNSClient(&http.Client{Transport: transport}, server.URL, server.ServerID, server.APIKey)
+
+	contents := func(name, rrType string) []string {
+		rrset, ok := server.Rrset("example.com.", name, rrType)
+		if !ok {
+			return nil
+		}
+		var contents []string
+		for _, record := range rrset.Records {
+			contents = append(contents, record.Content)
+		}
+		return contents
+	}
+
+	return pdns_client.NewRrsetBatcher(client, window), transport, contents
+}
+
+func replaceA(name string, content string) []pdns_client.Rrset {
+	return []pdns_client.Rrset{{
+		Name:       name,
+		Type:       "A",
+		Changetype: "REPLACE",
+		TTL:        300,
+		Records:    []pdns_client.Record{{Content: content}},
+	}}
+}
+
+func TestRrsetBatcher_coalesces(t *testing.T) {
+	batcher, transport, contents := newTestBatcher(t, 100*time.Millisecond, 0)
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Go(func() {
+			name := fmt.Sprintf("host%d.example.com.", i)
+			if err := batcher.UpdateZoneRecords(context.Background(), "example.com.", replaceA(name, "192.0.2.10")); err != nil {
+				t.Errorf("%s: %s", name, err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := transport.patches.Load(); got != 1 {
+		t.Errorf("PATCH requests = %d, want 1", got)
+	}
+	for i := range 10 {
+		if got := contents(fmt.Sprintf("host%d.example.com.", i), "A"); len(got) != 1 {
+			t.Errorf("host%d records = %v, want one record", i, got)
+		}
+	}
+}
+
+func TestRrsetBatcher_conflictFlushKeepsOrder(t *testing.T) {
+	// The first PATCH takes longer than the window, so without ordering the
+	// batch opened by the conflicting change would be sent while the first
+	// one is still in flight.
+	batcher, transport, contents := newTestBatcher(t, 10*time.Millisecond, 50*time.Millisecond)
+
+	first := make(chan error, 1)
+	go func() {
+		first <- batcher.UpdateZoneRecords(context.Background(), "example.com.", replaceA("www.example.com.", "192.0.2.1"))
+	}()
+	time.Sleep(2 * time.Millisecond)
+
+	// The same rrset cannot be part of the pending batch, so this change
+	// flushes the first one early and opens a new batch.
+	if err := batcher.UpdateZoneRecords(context.Background(), "example.com.", replaceA("www.example.com.", "192.0.2.2")); err != nil {
+		t.Fatalf("second change: %s", err)
+	}
+	if err := <-first; err != nil {
+		t.Fatalf("first change: %s", err)
+	}
+
+	if got := transport.patches.Load(); got != 2 {
+		t.Errorf("PATCH requests = %d, want 2", got)
+	}
+	if got := transport.maxInFlight.Load(); got != 1 {
+		t.Errorf("PATCH requests in flight at once = %d, want 1", got)
+	}
+	if got := contents("www.example.com.", "A"); len(got) != 1 || got[0] != "192.0.2.2" {
+		t.Errorf("records = %v, want the later change [192.0.2.2]", got)
+	}
+}
+
+func TestRrsetBatcher_rejectedBatchFallsBack(t *testing.T) {
+	batcher, transport, contents := newTestBatcher(t, 100*time.Millisecond, 0)
+
+	names := []string{"a.example.com.", "b.example.com.", "out.example.org.", "c.example.com."}
+	errs := make([]error, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Go(func() {
+			errs[i] = batcher.UpdateZoneRecords(context.Background(), "example.com.", replaceA(name, "192.0.2.10"))
+		})
+	}
+	wg.Wait()
+
+	for i, name := range names {
+		var apiError *pdns_client.PDNSAPIError
+		switch {
+		case name == "out.example.org." && !errors.As(errs[i], &apiError):
+			t.Errorf("%s: error = %v, want a PDNSAPIError", name, errs[i])
+		case name == "out.example.org." && apiError.StatusCode != http.StatusUnprocessableEntity:
+			t.Errorf("%s: status = %d, want 422", name, apiError.StatusCode)
+		case name != "out.example.org." && errs[i] != nil:
+			t.Errorf("%s: unexpected error: %s", name, errs[i])
+		case name != "out.example.org." && len(contents(name, "A")) != 1:
+			t.Errorf("%s was not applied", name)
+		}
+	}
+
+	// One rejected batch and one request per change.
+	if got := transport.patches.Load(); got != 5 {
+		t.Errorf("PATCH requests = %d, want 5", got)
+	}
+}
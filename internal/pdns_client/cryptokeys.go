@@ -0,0 +1,110 @@
+package pdns_client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Cryptokey is a DNSSEC key of a zone. KeyType is one of `ksk`, `zsk` or
+// `csk`. PrivateKey is only returned when a single key is requested and may be
+// set on creation to import an existing key.
+type Cryptokey struct {
+	Type       string   `json:"type,omitempty"`
+	ID         int64    `json:"id,omitempty"`
+	KeyType    string   `json:"keytype,omitempty"`
+	Active     bool     `json:"active"`
+	Published  bool     `json:"published"`
+	DNSKey     string   `json:"dnskey,omitempty"`
+	DS         []string `json:"ds,omitempty"`
+	CDS        []string `json:"cds,omitempty"`
+	PrivateKey string   `json:"privatekey,omitempty"`
+	Algorithm  string   `json:"algorithm,omitempty"`
+	Bits       int64    `json:"bits,omitempty"`
+}
+
+func cryptokeysPath(zoneID string) string {
+	return fmt.Sprintf("zones/%s/cryptokeys", url.QueryEscape(zoneID))
+}
+
+func (client *PDNSClient) ListCryptokeys(ctx context.Context, zoneID string) ([]Cryptokey, error) {
+	resp, err := client.do(ctx, http.MethodGet, cryptokeysPath(zoneID), nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var cryptokeys []Cryptokey
+	if err := json.NewDecoder(resp.Body).Decode(&cryptokeys); err != nil {
+		return nil, err
+	}
+
+	return cryptokeys, nil
+}
+
+func (client *PDNSClient) GetCryptokey(ctx context.Context, zoneID string, cryptokeyID int64) (Cryptokey, error) {
+	apiPath := fmt.Sprintf("%s/%d", cryptokeysPath(zoneID), cryptokeyID)
+	resp, err := client.do(ctx, http.MethodGet, apiPath, nil, http.StatusOK)
+	if err != nil {
+		return Cryptokey{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var cryptokey Cryptokey
+	if err := json.NewDecoder(resp.Body).Decode(&cryptokey); err != nil {
+		return Cryptokey{}, err
+	}
+
+	return cryptokey, nil
+}
+
+// CreateCryptokey generates a new key, or imports cryptokey.PrivateKey if set,
+// and returns it including the id assigned by PowerDNS.
+func (client *PDNSClient) CreateCryptokey(ctx context.Context, zoneID string, cryptokey Cryptokey) (Cryptokey, error) {
+	data, err := json.Marshal(cryptokey)
+	if err != nil {
+		return Cryptokey{}, err
+	}
+
+	resp, err := client.do(ctx, http.MethodPost, cryptokeysPath(zoneID), data, http.StatusCreated)
+	if err != nil {
+		return Cryptokey{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var created Cryptokey
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return Cryptokey{}, err
+	}
+
+	return created, nil
+}
+
+// SetCryptokeyState activates or deactivates and publishes or unpublishes a
+// key. These are the only properties PowerDNS allows to change.
+func (client *PDNSClient) SetCryptokeyState(ctx context.Context, zoneID string, cryptokeyID int64, active bool, published bool) error {
+	data, err := json.Marshal(Cryptokey{Active: active, Published: published})
+	if err != nil {
+		return err
+	}
+
+	apiPath := fmt.Sprintf("%s/%d", cryptokeysPath(zoneID), cryptokeyID)
+	resp, err := client.do(ctx, http.MethodPut, apiPath, data, http.StatusNoContent)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+func (client *PDNSClient) DeleteCryptokey(ctx context.Context, zoneID string, cryptokeyID int64) error {
+	apiPath := fmt.Sprintf("%s/%d", cryptokeysPath(zoneID), cryptokeyID)
+	resp, err := client.do(ctx, http.MethodDelete, apiPath, nil, http.StatusNoContent)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
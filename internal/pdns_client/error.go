@@ -1,5 +1,28 @@
 package pdns_client
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// notFoundError is implemented by all errors reporting a missing object.
+type notFoundError interface {
+	error
+	notFound()
+}
+
+// IsNotFound reports whether err reports that a zone, rrset, cryptokey, TSIG
+// key or server does not exist.
+func IsNotFound(err error) bool {
+	var target notFoundError
+	return errors.As(err, &target)
+}
+
 type PDNSZoneNotFoundError struct {
 	ZoneID string
 }
@@ -8,8 +31,138 @@ func (e *PDNSZoneNotFoundError) Error() string {
 	return "This zone was not found: " + e.ZoneID
 }
 
+func (e *PDNSZoneNotFoundError) notFound() {}
+
+type PDNSRrsetNotFoundError struct {
+	ZoneID string
+	Name   string
+	Type   string
+}
+
+func (e *PDNSRrsetNotFoundError) Error() string {
+	return fmt.Sprintf("No rrset with name '%s' and type '%s' was found in zone %s", e.Name, e.Type, e.ZoneID)
+}
+
+func (e *PDNSRrsetNotFoundError) notFound() {}
+
+type PDNSCryptokeyNotFoundError struct {
+	ZoneID      string
+	CryptokeyID string
+}
+
+func (e *PDNSCryptokeyNotFoundError) Error() string {
+	return fmt.Sprintf("This cryptokey was not found in zone %s: %s", e.ZoneID, e.CryptokeyID)
+}
+
+func (e *PDNSCryptokeyNotFoundError) notFound() {}
+
+type PDNSTSIGKeyNotFoundError struct {
+	KeyID string
+}
+
+func (e *PDNSTSIGKeyNotFoundError) Error() string {
+	return "This TSIG key was not found: " + e.KeyID
+}
+
+func (e *PDNSTSIGKeyNotFoundError) notFound() {}
+
+type PDNSServerNotFoundError struct {
+	ServerID string
+}
+
+func (e *PDNSServerNotFoundError) Error() string {
+	return "This server was not found: " + e.ServerID
+}
+
+func (e *PDNSServerNotFoundError) notFound() {}
+
 type PDNSUnauthorizedError struct{}
 
 func (e *PDNSUnauthorizedError) Error() string {
 	return "Not authorized to access PDNS API"
 }
+
+// PDNSAPIError is returned for responses that are not mapped to a more
+// specific error. It carries the messages from PowerDNS's
+// `{"error": ..., "errors": [...]}` body.
+type PDNSAPIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	Message    string
+	Errors     []string
+}
+
+func (e *PDNSAPIError) Error() string {
+	msg := fmt.Sprintf("PDNS API returned status %d for %s %s", e.StatusCode, e.Method, e.Path)
+	if details := e.Details(); details != "" {
+		msg += ": " + details
+	}
+	return msg
+}
+
+// Details returns the error messages sent by PowerDNS, one per line.
+func (e *PDNSAPIError) Details() string {
+	messages := make([]string, 0, len(e.Errors)+1)
+	if e.Message != "" {
+		messages = append(messages, e.Message)
+	}
+	for _, msg := range e.Errors {
+		if msg != e.Message {
+			messages = append(messages, msg)
+		}
+	}
+	return strings.Join(messages, "\n")
+}
+
+// newAPIError builds a PDNSAPIError from an unexpected response. Bodies that
+// are not PowerDNS error documents are kept verbatim as the message.
+func newAPIError(method, apiPath string, resp *http.Response) *PDNSAPIError {
+	apiErr := &PDNSAPIError{
+		StatusCode: resp.StatusCode,
+		Method:     method,
+		Path:       apiPath,
+	}
+
+	data, _ := io.ReadAll(resp.Body)
+
+	var body struct {
+		Error  string   `json:"error"`
+		Errors []string `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &body); err == nil && (body.Error != "" || len(body.Errors) > 0) {
+		apiErr.Message = body.Error
+		apiErr.Errors = body.Errors
+	} else {
+		apiErr.Message = strings.TrimSpace(string(data))
+	}
+
+	return apiErr
+}
+
+// newNotFoundError maps a 404 response to the typed error of the object the
+// request addressed. PowerDNS answers "Could not find domain" when the zone
+// itself is missing, even for requests on objects below a zone, and a plain
+// "Not Found" when the server id is unknown.
+func newNotFoundError(serverID, apiPath, message string) error {
+	apiPath, _, _ = strings.Cut(apiPath, "?")
+	segments := strings.Split(apiPath, "/")
+	for i, segment := range segments {
+		if unescaped, err := url.QueryUnescape(segment); err == nil {
+			segments[i] = unescaped
+		}
+	}
+
+	switch {
+	case len(segments) >= 2 && segments[0] == "zones" && strings.Contains(message, "Could not find domain"):
+		return &PDNSZoneNotFoundError{ZoneID: segments[1]}
+	case len(segments) >= 4 && segments[0] == "zones" && segments[2] == "cryptokeys":
+		return &PDNSCryptokeyNotFoundError{ZoneID: segments[1], CryptokeyID: segments[3]}
+	case len(segments) >= 2 && segments[0] == "tsigkeys":
+		return &PDNSTSIGKeyNotFoundError{KeyID: segments[1]}
+	case len(segments) >= 2 && segments[0] == "zones" && message != "Not Found":
+		return &PDNSZoneNotFoundError{ZoneID: segments[1]}
+	default:
+		return &PDNSServerNotFoundError{ServerID: serverID}
+	}
+}
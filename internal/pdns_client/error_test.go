@@ -0,0 +1,57 @@
+package pdns_client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewNotFoundError(t *testing.T) {
+	tests := map[string]struct {
+		apiPath string
+		message string
+		want    error
+	}{
+		"zone": {
+			apiPath: "zones/example.com.?rrsets=true&rrset_name=www.example.com.",
+			message: "Could not find domain 'example.com.'",
+			want:    &PDNSZoneNotFoundError{ZoneID: "example.com."},
+		},
+		"escaped zone id": {
+			apiPath: "zones/10.in-addr.arpa.%2F8",
+			message: "Could not find domain '10.in-addr.arpa./8'",
+			want:    &PDNSZoneNotFoundError{ZoneID: "10.in-addr.arpa./8"},
+		},
+		"zone below cryptokeys": {
+			apiPath: "zones/example.com./cryptokeys/3",
+			message: "Could not find domain 'example.com.'",
+			want:    &PDNSZoneNotFoundError{ZoneID: "example.com."},
+		},
+		"cryptokey": {
+			apiPath: "zones/example.com./cryptokeys/3",
+			message: "Could not find cryptokey",
+			want:    &PDNSCryptokeyNotFoundError{ZoneID: "example.com.", CryptokeyID: "3"},
+		},
+		"TSIG key": {
+			apiPath: "tsigkeys/axfr.",
+			message: "TSIG key with name 'axfr.' not found",
+			want:    &PDNSTSIGKeyNotFoundError{KeyID: "axfr."},
+		},
+		"server": {
+			apiPath: "zones/example.com.",
+			message: "Not Found",
+			want:    &PDNSServerNotFoundError{ServerID: "localhost"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := newNotFoundError("localhost", test.apiPath, test.message)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("newNotFoundError(%q, %q) = %#v, want %#v", test.apiPath, test.message, got, test.want)
+			}
+			if !IsNotFound(got) {
+				t.Errorf("IsNotFound(%#v) = false", got)
+			}
+		})
+	}
+}
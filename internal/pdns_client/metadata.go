@@ -0,0 +1,82 @@
+package pdns_client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Metadata holds the values of one metadata kind of a zone, e.g.
+// `ALLOW-AXFR-FROM`. PowerDNS reports an unset kind with no values.
+type Metadata struct {
+	Type     string   `json:"type,omitempty"`
+	Kind     string   `json:"kind,omitempty"`
+	Metadata []string `json:"metadata"`
+}
+
+func metadataPath(zoneID string) string {
+	return fmt.Sprintf("zones/%s/metadata", url.QueryEscape(zoneID))
+}
+
+func (client *PDNSClient) ListMetadata(ctx context.Context, zoneID string) ([]Metadata, error) {
+	resp, err := client.do(ctx, http.MethodGet, metadataPath(zoneID), nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var metadata []Metadata
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+func (client *PDNSClient) GetMetadata(ctx context.Context, zoneID string, kind string) (Metadata, error) {
+	resp, err := client.do(ctx, http.MethodGet, metadataPath(zoneID)+"/"+url.QueryEscape(kind), nil, http.StatusOK)
+	if err != nil {
+		return Metadata{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var metadata Metadata
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return Metadata{}, err
+	}
+
+	return metadata, nil
+}
+
+// SetMetadata replaces all values of a metadata kind and returns the stored
+// metadata.
+func (client *PDNSClient) SetMetadata(ctx context.Context, zoneID string, kind string, values []string) (Metadata, error) {
+	data, err := json.Marshal(Metadata{Kind: kind, Metadata: values})
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	resp, err := client.do(ctx, http.MethodPut, metadataPath(zoneID)+"/"+url.QueryEscape(kind), data, http.StatusOK)
+	if err != nil {
+		return Metadata{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var metadata Metadata
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return Metadata{}, err
+	}
+
+	return metadata, nil
+}
+
+func (client *PDNSClient) DeleteMetadata(ctx context.Context, zoneID string, kind string) error {
+	resp, err := client.do(ctx, http.MethodDelete, metadataPath(zoneID)+"/"+url.QueryEscape(kind), nil, http.StatusNoContent)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
@@ -8,23 +8,37 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
 )
 
 type PDNSClient struct {
-	httpClient *http.Client
-	serverID   string
-	apiKey     string
-	endpoint   string
+	httpClient   *http.Client
+	serverID     string
+	apiKey       string
+	endpoint     string
+	maxRetries   int
+	retryMinWait time.Duration
+	retryMaxWait time.Duration
+	limiter      *rate.Limiter
+	inFlight     chan struct{}
 }
 
-func NewPDNSClient(httpClient *http.Client, endpoint string, serverID string, apiKey string) *PDNSClient {
+func NewPDNSClient(httpClient *http.Client, endpoint string, serverID string, apiKey string, opts ...Option) *PDNSClient {
 	client := &PDNSClient{
-		httpClient: httpClient,
-		serverID:   serverID,
-		apiKey:     apiKey,
-		endpoint:   endpoint,
+		httpClient:   httpClient,
+		serverID:     serverID,
+		apiKey:       apiKey,
+		endpoint:     endpoint,
+		retryMinWait: DefaultRetryMinWait,
+		retryMaxWait: DefaultRetryMaxWait,
+	}
+
+	for _, opt := range opts {
+		opt(client)
 	}
 
 	return client
@@ -53,7 +67,25 @@ type PDNSZone struct {
 	Dnssec           bool     `json:"dnssec,omitempty"`
 	Nsec3Narrow      bool     `json:"nsec3narrow,omitempty"`
 	Presigned        bool     `json:"presigned,omitempty"`
-	APIRectify       bool     `json:"api_rectify,omitempty"`
+	APIRectify       *bool    `json:"api_rectify,omitempty"`
+}
+
+// PDNSZoneUpdate holds the zone properties changed by UpdateZone. PowerDNS only
+// touches the properties present in the request, so nil fields are left as
+// they are, while pointers to zero values explicitly reset a property.
+type PDNSZoneUpdate struct {
+	Kind             string    `json:"kind,omitempty"`
+	Dnssec           *bool     `json:"dnssec,omitempty"`
+	Nsec3Param       *string   `json:"nsec3param,omitempty"`
+	Nsec3Narrow      *bool     `json:"nsec3narrow,omitempty"`
+	Presigned        *bool     `json:"presigned,omitempty"`
+	APIRectify       *bool     `json:"api_rectify,omitempty"`
+	Catalog          *string   `json:"catalog,omitempty"`
+	SOAEdit          *string   `json:"soa_edit,omitempty"`
+	SOAEditAPI       *string   `json:"soa_edit_api,omitempty"`
+	Masters          *[]string `json:"masters,omitempty"`
+	MasterTsigKeyIDS *[]string `json:"master_tsig_key_ids,omitempty"`
+	SlaveTsigKeyIDS  *[]string `json:"slave_tsig_key_ids,omitempty"`
 }
 
 type Rrset struct {
@@ -65,6 +97,21 @@ type Rrset struct {
 	TTL        int64     `json:"ttl"`
 }
 
+// MarshalJSON leaves out the comments only if Comments is nil. PowerDNS keeps
+// the comments of a replaced rrset when the key is missing, so an empty slice
+// is sent as `[]` to remove them.
+func (rrset Rrset) MarshalJSON() ([]byte, error) {
+	type plainRrset Rrset
+	data := struct {
+		plainRrset
+		Comments *[]Comment `json:"comments,omitempty"`
+	}{plainRrset: plainRrset(rrset)}
+	if rrset.Comments != nil {
+		data.Comments = &rrset.Comments
+	}
+	return json.Marshal(data)
+}
+
 type Comment struct {
 	Content    string `json:"content,omitempty"`
 	Account    string `json:"account,omitempty"`
@@ -101,17 +148,60 @@ func (client *PDNSClient) getReq(ctx context.Context, method string, apiPath str
 // do builds and executes a request against the PDNS API and validates the
 // response status. It returns the response only when the status equals
 // wantStatus; otherwise it maps well-known status codes to typed errors
-// (PDNSUnauthorizedError, PDNSZoneNotFoundError) or a generic error carrying the
-// response body. Callers that get a non-nil response own closing its body.
-func (client *PDNSClient) do(ctx context.Context, method, apiPath, zoneID string, body io.Reader, wantStatus int) (*http.Response, error) {
-	req, err := client.getReq(ctx, method, apiPath, body)
-	if err != nil {
-		return nil, err
-	}
+// (PDNSUnauthorizedError, the not found errors) or a PDNSAPIError carrying the
+// messages from the response body. Every attempt waits for the rate and
+// concurrency budget (see WithRateLimit and WithMaxConcurrentRequests), and
+// idempotent requests are retried on transport errors and transient statuses
+// as configured via WithRetries. Callers that get a non-nil response own
+// closing its body.
+func (client *PDNSClient) do(ctx context.Context, method, apiPath string, body []byte, wantStatus int) (*http.Response, error) {
+	retryable := isIdempotent(method, body)
 
-	resp, err := client.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
+
+		req, err := client.getReq(ctx, method, apiPath, bodyReader)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err = client.send(req)
+
+		transient := (err != nil && ctx.Err() == nil) || (err == nil && isRetryableStatus(resp.StatusCode))
+		if !retryable || !transient || attempt >= client.maxRetries {
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+
+		wait := client.retryWait(attempt, resp)
+		logFields := map[string]interface{}{
+			"method":  method,
+			"path":    apiPath,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			logFields["error"] = err.Error()
+		} else {
+			logFields["status"] = resp.StatusCode
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		tflog.Warn(ctx, "Retrying request to PDNS API", logFields)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
 	}
 
 	if resp.StatusCode == wantStatus {
@@ -123,10 +213,9 @@ func (client *PDNSClient) do(ctx context.Context, method, apiPath, zoneID string
 	case http.StatusUnauthorized:
 		return nil, &PDNSUnauthorizedError{}
 	case http.StatusNotFound:
-		return nil, &PDNSZoneNotFoundError{ZoneID: zoneID}
+		return nil, newNotFoundError(client.serverID, apiPath, newAPIError(method, apiPath, resp).Details())
 	default:
-		data, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, data)
+		return nil, newAPIError(method, apiPath, resp)
 	}
 }
 
@@ -136,7 +225,7 @@ func (client *PDNSClient) GetZone(ctx context.Context, zoneID string, withRrsets
 	}
 
 	apiPath := fmt.Sprintf("zones/%s?rrsets=%t%s", url.QueryEscape(zoneID), withRrsets, limitToName)
-	resp, err := client.do(ctx, http.MethodGet, apiPath, zoneID, nil, http.StatusOK)
+	resp, err := client.do(ctx, http.MethodGet, apiPath, nil, http.StatusOK)
 	if err != nil {
 		return PDNSZone{}, err
 	}
@@ -150,8 +239,63 @@ func (client *PDNSClient) GetZone(ctx context.Context, zoneID string, withRrsets
 	return zone, nil
 }
 
+// ListZones returns all zones of the server without their rrsets. If zoneName
+// is set only the zone with exactly that name is returned. Setting
+// includeDNSSec to false leaves out the dnssec and edited_serial fields, which
+// makes listing considerably faster on servers with many zones.
+func (client *PDNSClient) ListZones(ctx context.Context, zoneName string, includeDNSSec bool) ([]PDNSZone, error) {
+	query := url.Values{}
+	query.Set("dnssec", strconv.FormatBool(includeDNSSec))
+	if zoneName != "" {
+		query.Set("zone", zoneName)
+	}
+
+	resp, err := client.do(ctx, http.MethodGet, "zones?"+query.Encode(), nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var zones []PDNSZone
+	if err := json.NewDecoder(resp.Body).Decode(&zones); err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+// GetRrset returns the rrset with the given fully qualified name and type, or a
+// PDNSRrsetNotFoundError if the zone has none.
+func (client *PDNSClient) GetRrset(ctx context.Context, zoneID string, name string, rrType string) (Rrset, error) {
+	apiPath := fmt.Sprintf(
+		"zones/%s?rrsets=true&rrset_name=%s&rrset_type=%s",
+		url.QueryEscape(zoneID),
+		url.QueryEscape(name),
+		url.QueryEscape(rrType),
+	)
+	resp, err := client.do(ctx, http.MethodGet, apiPath, nil, http.StatusOK)
+	if err != nil {
+		return Rrset{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var zone PDNSZone
+	if err := json.NewDecoder(resp.Body).Decode(&zone); err != nil {
+		return Rrset{}, err
+	}
+
+	// Servers before PowerDNS 4.8 ignore rrset_type, so filter here as well.
+	for _, rrset := range zone.Rrsets {
+		if rrset.Name == name && rrset.Type == rrType {
+			return rrset, nil
+		}
+	}
+
+	return Rrset{}, &PDNSRrsetNotFoundError{ZoneID: zoneID, Name: name, Type: rrType}
+}
+
 func (client *PDNSClient) DeleteZone(ctx context.Context, zoneID string) error {
-	resp, err := client.do(ctx, http.MethodDelete, "zones/"+url.QueryEscape(zoneID), zoneID, nil, http.StatusNoContent)
+	resp, err := client.do(ctx, http.MethodDelete, "zones/"+url.QueryEscape(zoneID), nil, http.StatusNoContent)
 	if err != nil {
 		return err
 	}
@@ -165,22 +309,27 @@ func (client *PDNSClient) CreateZone(ctx context.Context, zone PDNSZone) (PDNSZo
 		return PDNSZone{}, err
 	}
 
-	resp, err := client.do(ctx, http.MethodPost, "zones", zone.ID, bytes.NewReader(data), http.StatusCreated)
+	resp, err := client.do(ctx, http.MethodPost, "zones", data, http.StatusCreated)
 	if err != nil {
 		return PDNSZone{}, err
 	}
-	_ = resp.Body.Close()
+	defer func() { _ = resp.Body.Close() }()
+
+	var created PDNSZone
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return PDNSZone{}, err
+	}
 
-	return PDNSZone{}, nil
+	return created, nil
 }
 
-func (client *PDNSClient) UpdateZone(ctx context.Context, zoneID string, zone PDNSZone) error {
+func (client *PDNSClient) UpdateZone(ctx context.Context, zoneID string, zone PDNSZoneUpdate) error {
 	data, err := json.Marshal(zone)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.do(ctx, http.MethodPut, "zones/"+url.QueryEscape(zoneID), zoneID, bytes.NewReader(data), http.StatusNoContent)
+	resp, err := client.do(ctx, http.MethodPut, "zones/"+url.QueryEscape(zoneID), data, http.StatusNoContent)
 	if err != nil {
 		return err
 	}
@@ -194,7 +343,7 @@ func (client *PDNSClient) UpdateZoneRecords(ctx context.Context, zoneID string,
 		return err
 	}
 
-	resp, err := client.do(ctx, http.MethodPatch, "zones/"+url.QueryEscape(zoneID), zoneID, bytes.NewReader(data), http.StatusNoContent)
+	resp, err := client.do(ctx, http.MethodPatch, "zones/"+url.QueryEscape(zoneID), data, http.StatusNoContent)
 	if err != nil {
 		return err
 	}
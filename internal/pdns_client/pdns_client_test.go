@@ -0,0 +1,294 @@
+package pdns_client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdnstest"
+)
+
+func newTestClient(t *testing.T, opts ...pdns_client.Option) (*pdns_client.PDNSClient, *pdnstest.Server) {
+	t.Helper()
+
+	server := pdnstest.NewServer()
+	t.Cleanup(server.Close)
+
+	server.SetZone(pdns_client.PDNSZone{
+		Name: "example.com.",
+		Kind: "Native",
+		Rrsets: []pdns_client.Rrset{
+			{Name: "example.com.", Type: "SOA", TTL: 3600, Records: []pdns_client.Record{{Content: "ns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600"}}},
+			{Name: "www.example.com.", Type: "A", TTL: 300, Records: []pdns_client.Record{{Content: "192.0.2.1"}}},
+		},
+	})
+
+	client := pdns_client.NewPDNSClient(server.Client(), server.URL, server.ServerID, server.APIKey, opts...)
+	return client, server
+}
+
+func TestRetries(t *testing.T) {
+	replace := []pdns_client.Rrset{{
+		Name:       "www.example.com.",
+		Type:       "A",
+		Changetype: "REPLACE",
+		TTL:        300,
+		Records:    []pdns_client.Record{{Content: "192.0.2.2"}},
+	}}
+
+	tests := map[string]struct {
+		failures     int
+		status       int
+		call         func(context.Context, *pdns_client.PDNSClient) error
+		wantStatus   int
+		wantRequests int
+	}{
+		"GET succeeds after transient failures": {
+			failures: 2,
+			status:   http.StatusServiceUnavailable,
+			call: func(ctx context.Context, client *pdns_client.PDNSClient) error {
+				_, err := client.GetZone(ctx, "example.com.", true, "")
+				return err
+			},
+			wantRequests: 3,
+		},
+		"GET gives up after max retries": {
+			failures: 5,
+			status:   http.StatusBadGateway,
+			call: func(ctx context.Context, client *pdns_client.PDNSClient) error {
+				_, err := client.ListZones(ctx, "", false)
+				return err
+			},
+			wantStatus:   http.StatusBadGateway,
+			wantRequests: 4,
+		},
+		"PATCH replacing rrsets is retried on 429": {
+			failures: 1,
+			status:   http.StatusTooManyRequests,
+			call: func(ctx context.Context, client *pdns_client.PDNSClient) error {
+				return client.UpdateZoneRecords(ctx, "example.com.", replace)
+			},
+			wantRequests: 2,
+		},
+		"POST is not retried": {
+			failures: 1,
+			status:   http.StatusServiceUnavailable,
+			call: func(ctx context.Context, client *pdns_client.PDNSClient) error {
+				_, err := client.CreateZone(ctx, pdns_client.PDNSZone{Name: "example.org.", Kind: "Native"})
+				return err
+			},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRequests: 1,
+		},
+		"client errors are not retried": {
+			failures: 1,
+			status:   http.StatusBadRequest,
+			call: func(ctx context.Context, client *pdns_client.PDNSClient) error {
+				_, err := client.GetZone(ctx, "example.com.", true, "")
+				return err
+			},
+			wantStatus:   http.StatusBadRequest,
+			wantRequests: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, server := newTestClient(t, pdns_client.WithRetries(3, time.Millisecond, 5*time.Millisecond))
+			server.FailNext(test.failures, test.status)
+
+			err := test.call(context.Background(), client)
+
+			var apiError *pdns_client.PDNSAPIError
+			switch {
+			case test.wantStatus == 0 && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case test.wantStatus != 0 && !errors.As(err, &apiError):
+				t.Errorf("error = %v, want a PDNSAPIError", err)
+			case test.wantStatus != 0 && apiError.StatusCode != test.wantStatus:
+				t.Errorf("status = %d, want %d", apiError.StatusCode, test.wantStatus)
+			}
+
+			if got := server.Requests(); got != test.wantRequests {
+				t.Errorf("requests = %d, want %d", got, test.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRetries_contextCanceled(t *testing.T) {
+	client, server := newTestClient(t, pdns_client.WithRetries(3, time.Minute, time.Minute))
+	server.FailNext(1, http.StatusServiceUnavailable)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetZone(ctx, "example.com.", true, "")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("waited %s for a canceled retry", elapsed)
+	}
+	if got := server.Requests(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestGetRrset_notFound(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	t.Run("missing zone", func(t *testing.T) {
+		_, err := client.GetRrset(context.Background(), "example.org.", "www.example.org.", "A")
+
+		var zoneErr *pdns_client.PDNSZoneNotFoundError
+		if !errors.As(err, &zoneErr) || zoneErr.ZoneID != "example.org." {
+			t.Errorf("error = %#v, want a PDNSZoneNotFoundError for example.org.", err)
+		}
+		if !pdns_client.IsNotFound(err) {
+			t.Error("IsNotFound = false")
+		}
+	})
+
+	t.Run("missing rrset", func(t *testing.T) {
+		_, err := client.GetRrset(context.Background(), "example.com.", "www.example.com.", "AAAA")
+
+		var rrsetErr *pdns_client.PDNSRrsetNotFoundError
+		if !errors.As(err, &rrsetErr) || rrsetErr.Name != "www.example.com." || rrsetErr.Type != "AAAA" {
+			t.Errorf("error = %#v, want a PDNSRrsetNotFoundError for www.example.com. AAAA", err)
+		}
+		var zoneErr *pdns_client.PDNSZoneNotFoundError
+		if errors.As(err, &zoneErr) {
+			t.Error("a missing rrset was reported as a missing zone")
+		}
+		if !pdns_client.IsNotFound(err) {
+			t.Error("IsNotFound = false")
+		}
+	})
+
+	t.Run("existing rrset", func(t *testing.T) {
+		rrset, err := client.GetRrset(context.Background(), "example.com.", "www.example.com.", "A")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rrset.Records) != 1 || rrset.Records[0].Content != "192.0.2.1" {
+			t.Errorf("records = %+v, want [192.0.2.1]", rrset.Records)
+		}
+	})
+}
+
+func TestRrsetMarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		comments []pdns_client.Comment
+		want     string
+	}{
+		"nil comments are left out": {
+			comments: nil,
+			want:     `{"name":"www.example.com.","type":"A","changetype":"REPLACE","ttl":300}`,
+		},
+		"no comments are sent as an empty list": {
+			comments: []pdns_client.Comment{},
+			want:     `{"name":"www.example.com.","type":"A","changetype":"REPLACE","ttl":300,"comments":[]}`,
+		},
+		"comments": {
+			comments: []pdns_client.Comment{{Content: "web", Account: "ops"}},
+			want:     `{"name":"www.example.com.","type":"A","changetype":"REPLACE","ttl":300,"comments":[{"content":"web","account":"ops"}]}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(pdns_client.Rrset{Name: "www.example.com.", Type: "A", Changetype: "REPLACE", TTL: 300, Comments: test.comments})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.want {
+				t.Errorf("json = %s, want %s", data, test.want)
+			}
+		})
+	}
+}
+
+// queryTransport records the query of every request it sends.
+type queryTransport struct {
+	queries []url.Values
+}
+
+func (transport *queryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport.queries = append(transport.queries, req.URL.Query())
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestListZones(t *testing.T) {
+	tests := map[string]struct {
+		zoneName      string
+		includeDNSSec bool
+		wantQuery     url.Values
+		wantZones     []string
+		wantDNSSec    bool
+	}{
+		"all zones": {
+			includeDNSSec: true,
+			wantQuery:     url.Values{"dnssec": {"true"}},
+			wantZones:     []string{"example.com.", "example.org."},
+			wantDNSSec:    true,
+		},
+		"without dnssec": {
+			wantQuery: url.Values{"dnssec": {"false"}},
+			wantZones: []string{"example.com.", "example.org."},
+		},
+		"by name": {
+			zoneName:      "example.org.",
+			includeDNSSec: true,
+			wantQuery:     url.Values{"dnssec": {"true"}, "zone": {"example.org."}},
+			wantZones:     []string{"example.org."},
+			wantDNSSec:    true,
+		},
+		"missing name": {
+			zoneName:  "example.net.",
+			wantQuery: url.Values{"dnssec": {"false"}, "zone": {"example.net."}},
+			wantZones: []string{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, server := newTestClient(t)
+			server.SetZone(pdns_client.PDNSZone{Name: "example.org.", Kind: "Native", Dnssec: true})
+
+			transport := &queryTransport{}
+			client := pdns_client.NewPDNSClient(&http.Client{Transport: transport}, server.URL, server.ServerID, server.APIKey)
+
+			zones, err := client.ListZones(context.Background(), test.zoneName, test.includeDNSSec)
+			if err != nil {
+				t.Fatalf("ListZones() failed: %s", err)
+			}
+
+			if len(transport.queries) != 1 || !maps.EqualFunc(transport.queries[0], test.wantQuery, slices.Equal) {
+				t.Errorf("queries = %v, want %v", transport.queries, test.wantQuery)
+			}
+
+			names := make([]string, 0, len(zones))
+			for _, zone := range zones {
+				names = append(names, zone.Name)
+				if len(zone.Rrsets) != 0 {
+					t.Errorf("zone %s is listed with rrsets", zone.Name)
+				}
+				if zone.Name == "example.org." && zone.Dnssec != test.wantDNSSec {
+					t.Errorf("dnssec of example.org. = %t, want %t", zone.Dnssec, test.wantDNSSec)
+				}
+			}
+			if !slices.Equal(names, test.wantZones) {
+				t.Errorf("zones = %q, want %q", names, test.wantZones)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package pdns_client
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// WithRateLimit limits the client to requestsPerSecond requests on average,
+// using a token bucket that allows short bursts of up to one second worth of
+// requests. Retries draw from the same budget.
+func WithRateLimit(requestsPerSecond float64) Option {
+	return func(client *PDNSClient) {
+		if requestsPerSecond <= 0 {
+			client.limiter = nil
+			return
+		}
+		burst := max(int(math.Ceil(requestsPerSecond)), 1)
+		client.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+}
+
+// WithMaxConcurrentRequests caps the number of requests in flight at the same
+// time. A request occupies its slot until its response body is closed.
+func WithMaxConcurrentRequests(maxConcurrent int) Option {
+	return func(client *PDNSClient) {
+		if maxConcurrent <= 0 {
+			client.inFlight = nil
+			return
+		}
+		client.inFlight = make(chan struct{}, maxConcurrent)
+	}
+}
+
+// acquire blocks until the rate limiter and the concurrency cap allow another
+// request. The returned function releases the concurrency slot and must be
+// called exactly once.
+func (client *PDNSClient) acquire(ctx context.Context) (func(), error) {
+	if client.limiter != nil {
+		if err := client.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if client.inFlight == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case client.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-client.inFlight })
+	}, nil
+}
+
+// releasingBody releases the concurrency slot of a request once its response
+// body has been closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// send performs a single HTTP exchange within the client's rate and
+// concurrency budget.
+func (client *PDNSClient) send(req *http.Request) (*http.Response, error) {
+	release, err := client.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
@@ -0,0 +1,147 @@
+package pdns_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newLimitTestServer serves every request after delay and records the highest
+// number of requests in flight. The paths .../404 and .../503 answer with that
+// status, any other path with a 200 and an empty JSON object.
+func newLimitTestServer(t *testing.T, delay time.Duration) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		time.Sleep(delay)
+
+		switch r.URL.Path {
+		case "/api/v1/servers/localhost/404":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "Not Found"}`))
+		case "/api/v1/servers/localhost/503":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &maxInFlight
+}
+
+func TestRateLimit(t *testing.T) {
+	server, _ := newLimitTestServer(t, 0)
+	client := NewPDNSClient(server.Client(), server.URL, "localhost", "", WithRateLimit(50))
+
+	// The burst covers the first 50 requests, the remaining 10 need another
+	// 200ms worth of tokens.
+	start := time.Now()
+	for range 60 {
+		resp, err := client.do(context.Background(), http.MethodGet, "zones", nil, http.StatusOK)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("60 requests at 50/s took %s, want at least 150ms", elapsed)
+	}
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	server, maxInFlight := newLimitTestServer(t, 20*time.Millisecond)
+	client := NewPDNSClient(server.Client(), server.URL, "localhost", "", WithMaxConcurrentRequests(2))
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			resp, err := client.do(context.Background(), http.MethodGet, "zones", nil, http.StatusOK)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_ = resp.Body.Close()
+		})
+	}
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got != 2 {
+		t.Errorf("max requests in flight = %d, want 2", got)
+	}
+}
+
+func TestMaxConcurrentRequests_slotHeldUntilBodyClosed(t *testing.T) {
+	server, _ := newLimitTestServer(t, 0)
+	client := NewPDNSClient(server.Client(), server.URL, "localhost", "", WithMaxConcurrentRequests(1))
+
+	resp, err := client.do(context.Background(), http.MethodGet, "zones", nil, http.StatusOK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.do(ctx, http.MethodGet, "zones", nil, http.StatusOK); err == nil {
+		t.Error("second request got a slot while the first body was open")
+	}
+
+	_ = resp.Body.Close()
+	_ = resp.Body.Close()
+	if got := len(client.inFlight); got != 0 {
+		t.Errorf("slots in use after closing the body = %d, want 0", got)
+	}
+}
+
+func TestMaxConcurrentRequests_releasedOnEveryPath(t *testing.T) {
+	server, _ := newLimitTestServer(t, 0)
+
+	tests := map[string]struct {
+		endpoint string
+		path     string
+		close    bool
+	}{
+		"success closed by caller": {endpoint: server.URL, path: "zones", close: true},
+		"unexpected status":        {endpoint: server.URL, path: "404"},
+		"retried status":           {endpoint: server.URL, path: "503"},
+		"transport error":          {endpoint: "http://127.0.0.1:0", path: "zones"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := NewPDNSClient(
+				server.Client(), test.endpoint, "localhost", "",
+				WithMaxConcurrentRequests(1),
+				WithRetries(1, time.Millisecond, time.Millisecond),
+			)
+
+			resp, err := client.do(context.Background(), http.MethodGet, test.path, nil, http.StatusOK)
+			if test.close {
+				if err != nil {
+					t.Fatal(err)
+				}
+				_ = resp.Body.Close()
+			} else if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if got := len(client.inFlight); got != 0 {
+				t.Errorf("slots in use = %d, want 0", got)
+			}
+		})
+	}
+}
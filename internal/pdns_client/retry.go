@@ -0,0 +1,111 @@
+package pdns_client
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryMinWait = 1 * time.Second
+	DefaultRetryMaxWait = 30 * time.Second
+)
+
+// Option configures optional behaviour of a PDNSClient.
+type Option func(*PDNSClient)
+
+// WithRetries makes the client retry idempotent requests up to maxRetries
+// times on transport errors and on 429 and 5xx responses. The wait between
+// attempts grows exponentially from minWait, is jittered and never exceeds
+// maxWait, unless the server asks for less via Retry-After.
+func WithRetries(maxRetries int, minWait, maxWait time.Duration) Option {
+	return func(client *PDNSClient) {
+		client.maxRetries = maxRetries
+		client.retryMinWait = minWait
+		client.retryMaxWait = maxWait
+	}
+}
+
+// isIdempotent reports whether a request may safely be sent more than once.
+// PATCH requests qualify when every rrset change replaces or deletes the whole
+// rrset, as applying those twice yields the same zone.
+func isIdempotent(method string, body []byte) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPatch:
+		var patch struct {
+			Rrsets []struct {
+				Changetype string `json:"changetype"`
+			} `json:"rrsets"`
+		}
+		if err := json.Unmarshal(body, &patch); err != nil || len(patch.Rrsets) == 0 {
+			return false
+		}
+		for _, rrset := range patch.Rrsets {
+			if rrset.Changetype != "REPLACE" && rrset.Changetype != "DELETE" {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryableStatus reports whether a response status indicates a transient
+// server side condition, e.g. PowerDNS restarting or its backend failing over.
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryWait returns how long to wait before the given retry attempt (starting
+// at 0). A Retry-After header on resp takes precedence over the computed
+// backoff; both are capped at the configured maximum wait.
+func (client *PDNSClient) retryWait(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, client.retryMaxWait)
+		}
+	}
+
+	wait := client.retryMinWait << attempt
+	if wait <= 0 || wait > client.retryMaxWait {
+		wait = client.retryMaxWait
+	}
+
+	// Jitter into [wait/2, wait] so that parallel resources do not retry in
+	// lockstep.
+	half := wait / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an
+// HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,101 @@
+package pdns_client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIsIdempotent(t *testing.T) {
+	tests := map[string]struct {
+		method string
+		body   string
+		want   bool
+	}{
+		"GET":                    {method: http.MethodGet, want: true},
+		"PUT":                    {method: http.MethodPut, body: `{"kind": "Native"}`, want: true},
+		"DELETE":                 {method: http.MethodDelete, want: true},
+		"POST":                   {method: http.MethodPost, body: `{"name": "example.com."}`, want: false},
+		"PATCH replace":          {method: http.MethodPatch, body: `{"rrsets": [{"changetype": "REPLACE"}]}`, want: true},
+		"PATCH replace + delete": {method: http.MethodPatch, body: `{"rrsets": [{"changetype": "REPLACE"}, {"changetype": "DELETE"}]}`, want: true},
+		"PATCH extend":           {method: http.MethodPatch, body: `{"rrsets": [{"changetype": "REPLACE"}, {"changetype": "EXTEND"}]}`, want: false},
+		"PATCH without rrsets":   {method: http.MethodPatch, body: `{}`, want: false},
+		"PATCH invalid body":     {method: http.MethodPatch, body: `{`, want: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isIdempotent(test.method, []byte(test.body)); got != test.want {
+				t.Errorf("isIdempotent(%s, %s) = %t, want %t", test.method, test.body, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := map[string]struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		"empty":        {value: "", wantOK: false},
+		"seconds":      {value: "7", want: 7 * time.Second, wantOK: true},
+		"negative":     {value: "-3", want: 0, wantOK: true},
+		"past date":    {value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0, wantOK: true},
+		"not a number": {value: "soon", wantOK: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseRetryAfter(test.value)
+			if ok != test.wantOK || got != test.want {
+				t.Errorf("parseRetryAfter(%q) = %s, %t, want %s, %t", test.value, got, ok, test.want, test.wantOK)
+			}
+		})
+	}
+
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if got, ok := parseRetryAfter(future); !ok || got <= 50*time.Second || got > time.Minute {
+		t.Errorf("parseRetryAfter(%q) = %s, %t, want about a minute", future, got, ok)
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	client := NewPDNSClient(http.DefaultClient, "", "localhost", "", WithRetries(3, time.Second, 10*time.Second))
+
+	retryAfter := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	t.Run("Retry-After", func(t *testing.T) {
+		if got := client.retryWait(0, retryAfter("4")); got != 4*time.Second {
+			t.Errorf("wait = %s, want 4s", got)
+		}
+		if got := client.retryWait(0, retryAfter("120")); got != 10*time.Second {
+			t.Errorf("wait = %s, want it capped at 10s", got)
+		}
+	})
+
+	t.Run("backoff", func(t *testing.T) {
+		tests := []struct {
+			attempt int
+			resp    *http.Response
+			want    time.Duration
+		}{
+			{attempt: 0, want: time.Second},
+			{attempt: 1, want: 2 * time.Second},
+			{attempt: 2, resp: retryAfter("invalid"), want: 4 * time.Second},
+			{attempt: 4, want: 10 * time.Second},
+			{attempt: 80, want: 10 * time.Second},
+		}
+
+		for _, test := range tests {
+			for range 20 {
+				got := client.retryWait(test.attempt, test.resp)
+				if got < test.want/2 || got > test.want {
+					t.Errorf("attempt %d: wait = %s, want within [%s, %s]", test.attempt, got, test.want/2, test.want)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package pdns_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// TSIGKey is a shared secret used to authenticate zone transfers. The ID is
+// derived from the name by PowerDNS. Key is only returned when a single key is
+// requested; if it is empty on creation PowerDNS generates one.
+type TSIGKey struct {
+	Type      string `json:"type,omitempty"`
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Algorithm string `json:"algorithm,omitempty"`
+	Key       string `json:"key,omitempty"`
+}
+
+func (client *PDNSClient) ListTSIGKeys(ctx context.Context) ([]TSIGKey, error) {
+	resp, err := client.do(ctx, http.MethodGet, "tsigkeys", nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var tsigKeys []TSIGKey
+	if err := json.NewDecoder(resp.Body).Decode(&tsigKeys); err != nil {
+		return nil, err
+	}
+
+	return tsigKeys, nil
+}
+
+func (client *PDNSClient) GetTSIGKey(ctx context.Context, keyID string) (TSIGKey, error) {
+	resp, err := client.do(ctx, http.MethodGet, "tsigkeys/"+url.QueryEscape(keyID), nil, http.StatusOK)
+	if err != nil {
+		return TSIGKey{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var tsigKey TSIGKey
+	if err := json.NewDecoder(resp.Body).Decode(&tsigKey); err != nil {
+		return TSIGKey{}, err
+	}
+
+	return tsigKey, nil
+}
+
+// CreateTSIGKey creates a key and returns it including its id and, if none was
+// given, the generated secret.
+func (client *PDNSClient) CreateTSIGKey(ctx context.Context, tsigKey TSIGKey) (TSIGKey, error) {
+	data, err := json.Marshal(tsigKey)
+	if err != nil {
+		return TSIGKey{}, err
+	}
+
+	resp, err := client.do(ctx, http.MethodPost, "tsigkeys", data, http.StatusCreated)
+	if err != nil {
+		return TSIGKey{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var created TSIGKey
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return TSIGKey{}, err
+	}
+
+	return created, nil
+}
+
+// UpdateTSIGKey changes the fields set in tsigKey and returns the updated key.
+// Renaming a key changes its id.
+func (client *PDNSClient) UpdateTSIGKey(ctx context.Context, keyID string, tsigKey TSIGKey) (TSIGKey, error) {
+	data, err := json.Marshal(tsigKey)
+	if err != nil {
+		return TSIGKey{}, err
+	}
+
+	resp, err := client.do(ctx, http.MethodPut, "tsigkeys/"+url.QueryEscape(keyID), data, http.StatusOK)
+	if err != nil {
+		return TSIGKey{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var updated TSIGKey
+	if err := json.NewDecoder(resp.Body).Decode(&updated); err != nil {
+		return TSIGKey{}, err
+	}
+
+	return updated, nil
+}
+
+func (client *PDNSClient) DeleteTSIGKey(ctx context.Context, keyID string) error {
+	resp, err := client.do(ctx, http.MethodDelete, "tsigkeys/"+url.QueryEscape(keyID), nil, http.StatusNoContent)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
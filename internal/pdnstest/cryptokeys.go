@@ -0,0 +1,240 @@
+package pdnstest
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+// dnssecAlgorithm describes a DNSSEC algorithm as understood by PowerDNS.
+type dnssecAlgorithm struct {
+	name        string
+	number      int
+	defaultBits int64
+}
+
+var dnssecAlgorithms = []dnssecAlgorithm{
+	{"RSASHA1", 5, 2048},
+	{"RSASHA1-NSEC3-SHA1", 7, 2048},
+	{"RSASHA256", 8, 2048},
+	{"RSASHA512", 10, 2048},
+	{"ECDSAP256SHA256", 13, 256},
+	{"ECDSAP384SHA384", 14, 384},
+	{"ED25519", 15, 256},
+	{"ED448", 16, 456},
+}
+
+// lookupAlgorithm resolves an algorithm given by name or number like PowerDNS
+// does. An empty value selects the PowerDNS default.
+func lookupAlgorithm(value string) (dnssecAlgorithm, bool) {
+	if value == "" {
+		value = "ECDSAP256SHA256"
+	}
+	number, err := strconv.Atoi(value)
+	for _, algorithm := range dnssecAlgorithms {
+		if strings.EqualFold(algorithm.name, value) || (err == nil && algorithm.number == number) {
+			return algorithm, true
+		}
+	}
+	return dnssecAlgorithm{}, false
+}
+
+// Cryptokeys returns copies of the cryptokeys of a zone.
+func (s *Server) Cryptokeys(zoneID string) []pdns_client.Cryptokey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cryptokeys := make([]pdns_client.Cryptokey, 0, len(s.cryptokeys[zoneID]))
+	for _, cryptokey := range s.cryptokeys[zoneID] {
+		cryptokeys = append(cryptokeys, copyCryptokey(*cryptokey))
+	}
+	return cryptokeys
+}
+
+// lookupCryptokey returns the cryptokey addressed by the request path. The
+// caller must hold s.mu.
+func (s *Server) lookupCryptokey(w http.ResponseWriter, r *http.Request) (*pdns_client.PDNSZone, int, bool) {
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return nil, 0, false
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("cryptokey_id"), 10, 64)
+	idx := slices.IndexFunc(s.cryptokeys[zone.ID], func(item *pdns_client.Cryptokey) bool {
+		return item.ID == id
+	})
+	if err != nil || idx < 0 {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return nil, 0, false
+	}
+	return zone, idx, true
+}
+
+func (s *Server) listCryptokeys(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return
+	}
+
+	cryptokeys := make([]pdns_client.Cryptokey, 0, len(s.cryptokeys[zone.ID]))
+	for _, cryptokey := range s.cryptokeys[zone.ID] {
+		listed := copyCryptokey(*cryptokey)
+		listed.PrivateKey = ""
+		cryptokeys = append(cryptokeys, listed)
+	}
+
+	writeJSON(w, http.StatusOK, cryptokeys)
+}
+
+func (s *Server) getCryptokey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, idx, ok := s.lookupCryptokey(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, copyCryptokey(*s.cryptokeys[zone.ID][idx]))
+}
+
+func (s *Server) createCryptokey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return
+	}
+
+	// PowerDNS publishes new keys unless told otherwise.
+	cryptokey := pdns_client.Cryptokey{Published: true}
+	if err := json.NewDecoder(r.Body).Decode(&cryptokey); err != nil {
+		writeError(w, http.StatusBadRequest, "Request body is not a valid JSON document: "+err.Error())
+		return
+	}
+
+	cryptokey, err := s.addCryptokey(zone, cryptokey)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, cryptokey)
+}
+
+// addCryptokey generates key material for cryptokey, stores it and marks the
+// zone as DNSSEC signed. The caller must hold s.mu.
+func (s *Server) addCryptokey(zone *pdns_client.PDNSZone, cryptokey pdns_client.Cryptokey) (pdns_client.Cryptokey, error) {
+	if !slices.Contains([]string{"ksk", "zsk", "csk"}, cryptokey.KeyType) {
+		return pdns_client.Cryptokey{}, fmt.Errorf("Invalid keytype '%s'", cryptokey.KeyType)
+	}
+
+	algorithm, ok := lookupAlgorithm(cryptokey.Algorithm)
+	if !ok {
+		return pdns_client.Cryptokey{}, fmt.Errorf("Unknown algorithm: '%s'", cryptokey.Algorithm)
+	}
+	if cryptokey.PrivateKey != "" && cryptokey.Bits != 0 {
+		return pdns_client.Cryptokey{}, errors.New("Key size ('bits') must not be set when importing a private key")
+	}
+	if cryptokey.Bits == 0 {
+		cryptokey.Bits = algorithm.defaultBits
+	}
+	if algorithm.number >= 13 && cryptokey.Bits != algorithm.defaultBits {
+		return pdns_client.Cryptokey{}, fmt.Errorf("The algorithm does not support the given bit size: %d", cryptokey.Bits)
+	}
+
+	s.nextKeyID++
+	cryptokey.ID = s.nextKeyID
+	cryptokey.Type = "Cryptokey"
+	cryptokey.Algorithm = algorithm.name
+
+	flags := 256
+	if cryptokey.KeyType != "zsk" {
+		flags = 257
+	}
+	seed := fmt.Sprintf("%s/%d/%s", zone.ID, cryptokey.ID, cryptokey.PrivateKey)
+	publicKey := sha256.Sum256([]byte(seed))
+	cryptokey.DNSKey = fmt.Sprintf("%d 3 %d %s", flags, algorithm.number, base64.StdEncoding.EncodeToString(publicKey[:]))
+	if cryptokey.PrivateKey == "" {
+		cryptokey.PrivateKey = fmt.Sprintf("Private-key-format: v1.2\nAlgorithm: %d (%s)\nPrivateKey: %s\n", algorithm.number, algorithm.name, base64.StdEncoding.EncodeToString([]byte(seed)))
+	}
+
+	cryptokey.DS = nil
+	cryptokey.CDS = nil
+	if flags == 257 {
+		keyTag := int(publicKey[0])<<8 | int(publicKey[1])
+		digest := sha256.Sum256([]byte(zone.Name + cryptokey.DNSKey))
+		cryptokey.DS = []string{
+			fmt.Sprintf("%d %d 2 %s", keyTag, algorithm.number, hex.EncodeToString(digest[:])),
+			fmt.Sprintf("%d %d 4 %s", keyTag, algorithm.number, hex.EncodeToString(append(digest[:], digest[:16]...))),
+		}
+		cryptokey.CDS = slices.Clone(cryptokey.DS)
+	}
+
+	s.cryptokeys[zone.ID] = append(s.cryptokeys[zone.ID], &cryptokey)
+	zone.Dnssec = true
+
+	return copyCryptokey(cryptokey), nil
+}
+
+func (s *Server) updateCryptokey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, idx, ok := s.lookupCryptokey(w, r)
+	if !ok {
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		writeError(w, http.StatusBadRequest, "Request body is not a valid JSON document: "+err.Error())
+		return
+	}
+
+	cryptokey := s.cryptokeys[zone.ID][idx]
+	for key, target := range map[string]*bool{"active": &cryptokey.Active, "published": &cryptokey.Published} {
+		if raw, ok := fields[key]; ok {
+			if err := json.Unmarshal(raw, target); err != nil {
+				writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("'%s' must be a boolean", key))
+				return
+			}
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteCryptokey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, idx, ok := s.lookupCryptokey(w, r)
+	if !ok {
+		return
+	}
+
+	s.cryptokeys[zone.ID] = slices.Delete(s.cryptokeys[zone.ID], idx, idx+1)
+	zone.Dnssec = len(s.cryptokeys[zone.ID]) > 0
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func copyCryptokey(cryptokey pdns_client.Cryptokey) pdns_client.Cryptokey {
+	cryptokey.DS = slices.Clone(cryptokey.DS)
+	cryptokey.CDS = slices.Clone(cryptokey.CDS)
+	return cryptokey
+}
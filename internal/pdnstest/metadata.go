@@ -0,0 +1,144 @@
+package pdnstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+var metadataKinds = []string{
+	"ALLOW-AXFR-FROM", "ALLOW-DNSUPDATE-FROM", "ALSO-NOTIFY", "API-RECTIFY", "AXFR-MASTER-TSIG", "AXFR-SOURCE",
+	"FORWARD-DNSUPDATE", "GSS-ACCEPTOR-PRINCIPAL", "GSS-ALLOW-AXFR-PRINCIPAL", "IXFR", "LUA-AXFR-SCRIPT",
+	"NOTIFY-DNSUPDATE", "NSEC3NARROW", "NSEC3PARAM", "PRESIGNED", "PUBLISH-CDNSKEY", "PUBLISH-CDS",
+	"SLAVE-RENOTIFY", "SOA-EDIT", "SOA-EDIT-API", "SOA-EDIT-DNSUPDATE", "TSIG-ALLOW-AXFR", "TSIG-ALLOW-DNSUPDATE",
+}
+
+// protectedMetadataKinds can be read but not changed through the metadata
+// endpoints, mostly because they are zone properties.
+var protectedMetadataKinds = []string{
+	"API-RECTIFY", "AXFR-MASTER-TSIG", "LUA-AXFR-SCRIPT", "NSEC3NARROW", "NSEC3PARAM", "PRESIGNED", "SOA-EDIT-API", "TSIG-ALLOW-AXFR",
+}
+
+func validMetadataKind(kind string, modify bool) bool {
+	if strings.HasPrefix(kind, "X-") {
+		return true
+	}
+	return slices.Contains(metadataKinds, kind) && !(modify && slices.Contains(protectedMetadataKinds, kind))
+}
+
+// Metadata returns a copy of the values of a metadata kind of a zone.
+func (s *Server) Metadata(zoneID, kind string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.metadata[zoneID][kind])
+}
+
+// SetMetadata stores metadata behind the provider's back, including kinds the
+// API does not allow to change.
+func (s *Server) SetMetadata(zoneID, kind string, values []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.metadata[zoneID] == nil {
+		s.metadata[zoneID] = make(map[string][]string)
+	}
+	s.metadata[zoneID][kind] = slices.Clone(values)
+}
+
+// lookupMetadataKind returns the zone and validated kind addressed by the
+// request path. The caller must hold s.mu.
+func (s *Server) lookupMetadataKind(w http.ResponseWriter, r *http.Request, modify bool) (*pdns_client.PDNSZone, string, bool) {
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return nil, "", false
+	}
+
+	kind := r.PathValue("metadata_kind")
+	if !validMetadataKind(kind, modify) {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Unsupported metadata kind '%s'", kind))
+		return nil, "", false
+	}
+	return zone, kind, true
+}
+
+func (s *Server) listMetadata(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return
+	}
+
+	metadata := make([]pdns_client.Metadata, 0, len(s.metadata[zone.ID]))
+	for kind, values := range s.metadata[zone.ID] {
+		metadata = append(metadata, pdns_client.Metadata{Type: "Metadata", Kind: kind, Metadata: slices.Clone(values)})
+	}
+	sort.Slice(metadata, func(i, j int) bool { return metadata[i].Kind < metadata[j].Kind })
+
+	writeJSON(w, http.StatusOK, metadata)
+}
+
+func (s *Server) getMetadata(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, kind, ok := s.lookupMetadataKind(w, r, false)
+	if !ok {
+		return
+	}
+
+	values := slices.Clone(s.metadata[zone.ID][kind])
+	if values == nil {
+		values = []string{}
+	}
+
+	writeJSON(w, http.StatusOK, pdns_client.Metadata{Type: "Metadata", Kind: kind, Metadata: values})
+}
+
+func (s *Server) updateMetadata(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, kind, ok := s.lookupMetadataKind(w, r, true)
+	if !ok {
+		return
+	}
+
+	var metadata pdns_client.Metadata
+	if err := json.NewDecoder(r.Body).Decode(&metadata); err != nil {
+		writeError(w, http.StatusBadRequest, "Request body is not a valid JSON document: "+err.Error())
+		return
+	}
+	if metadata.Metadata == nil {
+		writeError(w, http.StatusBadRequest, "Key 'metadata' not present or not an Array")
+		return
+	}
+
+	if s.metadata[zone.ID] == nil {
+		s.metadata[zone.ID] = make(map[string][]string)
+	}
+	s.metadata[zone.ID][kind] = slices.Clone(metadata.Metadata)
+
+	writeJSON(w, http.StatusOK, pdns_client.Metadata{Type: "Metadata", Kind: kind, Metadata: metadata.Metadata})
+}
+
+func (s *Server) deleteMetadata(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, kind, ok := s.lookupMetadataKind(w, r, true)
+	if !ok {
+		return
+	}
+
+	delete(s.metadata[zone.ID], kind)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,34 @@
+package pdnstest
+
+import (
+	"testing"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+func TestNormalizeRecords(t *testing.T) {
+	tests := []struct {
+		rrType  string
+		content string
+		want    string
+	}{
+		{rrType: "A", content: "192.0.2.1", want: "192.0.2.1"},
+		{rrType: "AAAA", content: "2001:0DB8:0000:0000:0000:0000:0000:0001", want: "2001:db8::1"},
+		{rrType: "AAAA", content: "not an address", want: "not an address"},
+		{rrType: "CNAME", content: "WWW.Example.COM.", want: "www.example.com."},
+		{rrType: "MX", content: "10  Mail.Example.COM.", want: "10 mail.example.com."},
+		{rrType: "SRV", content: "10 5 5060 SIP.example.com.", want: "10 5 5060 sip.example.com."},
+		{rrType: "CAA", content: `0 ISSUE "LetsEncrypt.org"`, want: `0 issue "LetsEncrypt.org"`},
+		{rrType: "TXT", content: `"Mixed Case"`, want: `"Mixed Case"`},
+		// Content without fields must not panic.
+		{rrType: "MX", content: "   ", want: "   "},
+		{rrType: "CNAME", content: "", want: ""},
+	}
+
+	for _, test := range tests {
+		rrset := normalizeRecords(pdns_client.Rrset{Type: test.rrType, Records: []pdns_client.Record{{Content: test.content}}})
+		if got := rrset.Records[0].Content; got != test.want {
+			t.Errorf("%s %q: normalized to %q, want %q", test.rrType, test.content, got, test.want)
+		}
+	}
+}
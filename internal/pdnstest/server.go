@@ -0,0 +1,730 @@
+// Package pdnstest provides an in-memory fake of the PowerDNS Authoritative
+// HTTP API for exercising pdns_client and the provider without a real server.
+package pdnstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+const (
+	DefaultAPIKey   = "test-api-key"
+	DefaultServerID = "localhost"
+
+	defaultSOA = "a.misconfigured.dns.server.invalid. hostmaster.%s 0 10800 3600 604800 3600"
+)
+
+var zoneKinds = []string{"Native", "Master", "Slave", "Producer", "Consumer"}
+
+// Server is a fake PowerDNS API server backed by an httptest.Server. Zones are
+// kept in memory and are only reachable through the configured server id and
+// API key, mirroring the behaviour of a real PowerDNS instance.
+type Server struct {
+	*httptest.Server
+
+	APIKey   string
+	ServerID string
+
+	mu         sync.Mutex
+	zones      map[string]*pdns_client.PDNSZone
+	cryptokeys map[string][]*pdns_client.Cryptokey
+	tsigKeys   map[string]*pdns_client.TSIGKey
+	metadata   map[string]map[string][]string
+	nextKeyID  int64
+	failures   []int
+	requests   int
+}
+
+// NewServer starts a fake server using DefaultAPIKey and DefaultServerID. The
+// caller must Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:     DefaultAPIKey,
+		ServerID:   DefaultServerID,
+		zones:      make(map[string]*pdns_client.PDNSZone),
+		cryptokeys: make(map[string][]*pdns_client.Cryptokey),
+		tsigKeys:   make(map[string]*pdns_client.TSIGKey),
+		metadata:   make(map[string]map[string][]string),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/servers/{server_id}/zones", s.listZones)
+	mux.HandleFunc("POST /api/v1/servers/{server_id}/zones", s.createZone)
+	mux.HandleFunc("GET /api/v1/servers/{server_id}/zones/{zone_id}", s.getZone)
+	mux.HandleFunc("PUT /api/v1/servers/{server_id}/zones/{zone_id}", s.updateZone)
+	mux.HandleFunc("PATCH /api/v1/servers/{server_id}/zones/{zone_id}", s.patchZone)
+	mux.HandleFunc("DELETE /api/v1/servers/{server_id}/zones/{zone_id}", s.deleteZone)
+	mux.HandleFunc("GET /api/v1/servers/{server_id}/zones/{zone_id}/cryptokeys", s.listCryptokeys)
+	mux.HandleFunc("POST /api/v1/servers/{server_id}/zones/{zone_id}/cryptokeys", s.createCryptokey)
+	mux.HandleFunc("GET /api/v1/servers/{server_id}/zones/{zone_id}/cryptokeys/{cryptokey_id}", s.getCryptokey)
+	mux.HandleFunc("PUT /api/v1/servers/{server_id}/zones/{zone_id}/cryptokeys/{cryptokey_id}", s.updateCryptokey)
+	mux.HandleFunc("DELETE /api/v1/servers/{server_id}/zones/{zone_id}/cryptokeys/{cryptokey_id}", s.deleteCryptokey)
+	mux.HandleFunc("GET /api/v1/servers/{server_id}/zones/{zone_id}/metadata", s.listMetadata)
+	mux.HandleFunc("GET /api/v1/servers/{server_id}/zones/{zone_id}/metadata/{metadata_kind}", s.getMetadata)
+	mux.HandleFunc("PUT /api/v1/servers/{server_id}/zones/{zone_id}/metadata/{metadata_kind}", s.updateMetadata)
+	mux.HandleFunc("DELETE /api/v1/servers/{server_id}/zones/{zone_id}/metadata/{metadata_kind}", s.deleteMetadata)
+	mux.HandleFunc("GET /api/v1/servers/{server_id}/tsigkeys", s.listTSIGKeys)
+	mux.HandleFunc("POST /api/v1/servers/{server_id}/tsigkeys", s.createTSIGKey)
+	mux.HandleFunc("GET /api/v1/servers/{server_id}/tsigkeys/{tsigkey_id}", s.getTSIGKey)
+	mux.HandleFunc("PUT /api/v1/servers/{server_id}/tsigkeys/{tsigkey_id}", s.updateTSIGKey)
+	mux.HandleFunc("DELETE /api/v1/servers/{server_id}/tsigkeys/{tsigkey_id}", s.deleteTSIGKey)
+
+	s.Server = httptest.NewServer(s.authenticate(mux))
+
+	return s
+}
+
+// Zone returns a copy of the stored zone with the given id.
+func (s *Server) Zone(zoneID string) (pdns_client.PDNSZone, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, ok := s.zones[zoneID]
+	if !ok {
+		return pdns_client.PDNSZone{}, false
+	}
+	return copyZone(*zone), true
+}
+
+// Rrset returns a copy of the rrset with the given name and type.
+func (s *Server) Rrset(zoneID, name, rrType string) (pdns_client.Rrset, bool) {
+	zone, ok := s.Zone(zoneID)
+	if !ok {
+		return pdns_client.Rrset{}, false
+	}
+
+	idx := slices.IndexFunc(zone.Rrsets, func(item pdns_client.Rrset) bool {
+		return item.Name == name && item.Type == rrType
+	})
+	if idx < 0 {
+		return pdns_client.Rrset{}, false
+	}
+	return zone.Rrsets[idx], true
+}
+
+// SetZone stores zone as is, replacing any zone with the same name. It is meant
+// for seeding state that was created "out of band".
+func (s *Server) SetZone(zone pdns_client.PDNSZone) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone.ID = zone.Name
+	zone.URL = "/api/v1/servers/" + s.ServerID + "/zones/" + zone.Name
+	zone.Type = "Zone"
+	zone.Serial = soaSerial(zone.Rrsets, zone.Name)
+	s.zones[zone.ID] = &zone
+}
+
+// RemoveZone deletes a zone behind the provider's back.
+func (s *Server) RemoveZone(zoneID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.zones, zoneID)
+	delete(s.cryptokeys, zoneID)
+	delete(s.metadata, zoneID)
+}
+
+// FailNext makes the next count requests fail with the given status before they
+// reach the API, e.g. to simulate a restarting server.
+func (s *Server) FailNext(count int, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for range count {
+		s.failures = append(s.failures, status)
+	}
+}
+
+// Requests returns the number of requests the server has received.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		var failure int
+		if len(s.failures) > 0 {
+			failure, s.failures = s.failures[0], s.failures[1:]
+		}
+		s.mu.Unlock()
+
+		if failure != 0 {
+			writeError(w, failure, http.StatusText(failure))
+			return
+		}
+
+		if r.Header.Get("X-API-Key") != s.APIKey {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) checkServer(w http.ResponseWriter, r *http.Request) bool {
+	if r.PathValue("server_id") != s.ServerID {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return false
+	}
+	return true
+}
+
+// lookupZone returns the zone addressed by the request path. The caller must
+// hold s.mu.
+func (s *Server) lookupZone(w http.ResponseWriter, r *http.Request) (*pdns_client.PDNSZone, bool) {
+	if !s.checkServer(w, r) {
+		return nil, false
+	}
+
+	zoneID := r.PathValue("zone_id")
+	zone, ok := s.zones[zoneID]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Could not find domain '%s'", zoneID))
+		return nil, false
+	}
+	return zone, true
+}
+
+func (s *Server) listZones(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	filter := r.URL.Query().Get("zone")
+	withDNSSec := r.URL.Query().Get("dnssec") != "false"
+
+	zones := make([]pdns_client.PDNSZone, 0, len(s.zones))
+	for _, zone := range s.zones {
+		if filter != "" && zone.Name != filter {
+			continue
+		}
+		listed := copyZone(*zone)
+		listed.Rrsets = nil
+		if !withDNSSec {
+			listed.Dnssec = false
+			listed.EditedSerial = 0
+		}
+		zones = append(zones, listed)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Name < zones[j].Name })
+
+	writeJSON(w, http.StatusOK, zones)
+}
+
+func (s *Server) getZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	result := copyZone(*zone)
+
+	if query.Get("rrsets") == "false" {
+		result.Rrsets = nil
+	} else if name := query.Get("rrset_name"); name != "" {
+		rrType := query.Get("rrset_type")
+		result.Rrsets = slices.DeleteFunc(result.Rrsets, func(item pdns_client.Rrset) bool {
+			return item.Name != name || (rrType != "" && item.Type != rrType)
+		})
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	var zone pdns_client.PDNSZone
+	if err := json.NewDecoder(r.Body).Decode(&zone); err != nil {
+		writeError(w, http.StatusBadRequest, "Request body is not a valid JSON document: "+err.Error())
+		return
+	}
+
+	if zone.Name == "" || !strings.HasSuffix(zone.Name, ".") {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("DNS Name '%s' is not canonical", zone.Name))
+		return
+	}
+	if _, exists := s.zones[zone.Name]; exists {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Domain '%s' already exists", zone.Name))
+		return
+	}
+	if zone.Kind == "" {
+		zone.Kind = "Native"
+	}
+	if !slices.Contains(zoneKinds, zone.Kind) {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Invalid zone kind '%s'", zone.Kind))
+		return
+	}
+
+	if zone.Kind == "Slave" || zone.Kind == "Consumer" {
+		if len(zone.Nameservers) > 0 {
+			writeError(w, http.StatusUnprocessableEntity, "Nameservers MUST NOT be given for Slave and Consumer zones")
+			return
+		}
+		if len(zone.Rrsets) > 0 {
+			writeError(w, http.StatusUnprocessableEntity, "Zone data MUST NOT be given for Slave and Consumer zones")
+			return
+		}
+	}
+	if err := normalizeMasters(&zone); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	rrsets := make([]pdns_client.Rrset, 0, len(zone.Rrsets)+2)
+	for _, rrset := range zone.Rrsets {
+		if rrset.Changetype != "" {
+			writeError(w, http.StatusUnprocessableEntity, "Key 'changetype' not allowed when creating a zone")
+			return
+		}
+		if err := validateRrset(zone.Name, rrset); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		rrsets = upsertRrset(rrsets, normalizeRecords(stampComments(rrset)))
+	}
+
+	if len(zone.Nameservers) > 0 {
+		ns := pdns_client.Rrset{Name: zone.Name, Type: "NS", TTL: 3600}
+		for _, nameserver := range zone.Nameservers {
+			ns.Records = append(ns.Records, pdns_client.Record{Content: nameserver})
+		}
+		rrsets = upsertRrset(rrsets, ns)
+	}
+
+	if !slices.ContainsFunc(rrsets, func(item pdns_client.Rrset) bool { return item.Type == "SOA" }) && zone.Kind != "Slave" && zone.Kind != "Consumer" {
+		rrsets = upsertRrset(rrsets, pdns_client.Rrset{
+			Name:    zone.Name,
+			Type:    "SOA",
+			TTL:     3600,
+			Records: []pdns_client.Record{{Content: fmt.Sprintf(defaultSOA, zone.Name)}},
+		})
+	}
+
+	if err := validateDNSSecSettings(zone); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := s.validateZoneTSIGKeys(zone); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	normalizeZoneTSIGKeys(&zone)
+	if zone.APIRectify == nil {
+		// Mirrors the default-api-rectify setting, which is on by default.
+		apiRectify := true
+		zone.APIRectify = &apiRectify
+	}
+	if zone.SOAEditAPI == "" {
+		zone.SOAEditAPI = "DEFAULT"
+	}
+	rrsets = editSOASerial(rrsets, zone.Name, zone.SOAEditAPI)
+
+	zone.ID = zone.Name
+	zone.URL = "/api/v1/servers/" + s.ServerID + "/zones/" + zone.Name
+	zone.Type = "Zone"
+	zone.Nameservers = nil
+	zone.Rrsets = rrsets
+	zone.Serial = soaSerial(rrsets, zone.Name)
+
+	s.zones[zone.ID] = &zone
+	if zone.Dnssec {
+		s.secureZone(&zone)
+	}
+
+	writeJSON(w, http.StatusCreated, copyZone(zone))
+}
+
+// updateZone applies only the keys present in the request body, like PowerDNS
+// does for PUT requests.
+func (s *Server) updateZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		writeError(w, http.StatusBadRequest, "Request body is not a valid JSON document: "+err.Error())
+		return
+	}
+
+	if _, ok := fields["rrsets"]; ok {
+		writeError(w, http.StatusUnprocessableEntity, "Modifying RRsets via PUT is not supported, use PATCH")
+		return
+	}
+	if _, ok := fields["name"]; ok {
+		writeError(w, http.StatusUnprocessableEntity, "Renaming a zone is not supported")
+		return
+	}
+
+	updated := copyZone(*zone)
+	if err := json.Unmarshal(body, &updated); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if !slices.Contains(zoneKinds, updated.Kind) {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Invalid zone kind '%s'", updated.Kind))
+		return
+	}
+	if err := validateDNSSecSettings(updated); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := s.validateZoneTSIGKeys(updated); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	normalizeZoneTSIGKeys(&updated)
+	if err := normalizeMasters(&updated); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	*zone = updated
+
+	if _, ok := fields["dnssec"]; ok {
+		if zone.Dnssec {
+			s.secureZone(zone)
+		} else {
+			delete(s.cryptokeys, zone.ID)
+			zone.Nsec3Param = ""
+			zone.Nsec3Narrow = false
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) patchZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return
+	}
+
+	var patch pdns_client.PDNSZone
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, "Request body is not a valid JSON document: "+err.Error())
+		return
+	}
+
+	// Validate everything first so that a rejected PATCH leaves the zone
+	// untouched, like the transactional behaviour of PowerDNS.
+	seen := make(map[string]bool, len(patch.Rrsets))
+	for _, rrset := range patch.Rrsets {
+		key := rrset.Name + "/" + rrset.Type
+		if seen[key] {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Duplicate RRset %s IN %s with changetype: %s", rrset.Name, rrset.Type, rrset.Changetype))
+			return
+		}
+		seen[key] = true
+
+		switch rrset.Changetype {
+		case "REPLACE":
+			if err := validateRrset(zone.Name, rrset); err != nil {
+				writeError(w, http.StatusUnprocessableEntity, err.Error())
+				return
+			}
+		case "DELETE":
+			if !inZone(rrset.Name, zone.Name) {
+				writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("RRset %s IN %s: Name is out of zone", rrset.Name, rrset.Type))
+				return
+			}
+		default:
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Changetype not understood: '%s'", rrset.Changetype))
+			return
+		}
+	}
+
+	rrsets := slices.Clone(zone.Rrsets)
+	for _, rrset := range patch.Rrsets {
+		// Like PowerDNS, a REPLACE without the comments key keeps the stored
+		// comments.
+		idx := slices.IndexFunc(rrsets, func(item pdns_client.Rrset) bool {
+			return item.Name == rrset.Name && item.Type == rrset.Type
+		})
+		if idx >= 0 {
+			if rrset.Comments == nil {
+				rrset.Comments = rrsets[idx].Comments
+			}
+			rrsets = slices.Delete(rrsets, idx, idx+1)
+		}
+		if rrset.Changetype == "REPLACE" && (len(rrset.Records) > 0 || len(rrset.Comments) > 0) {
+			rrset.Changetype = ""
+			rrsets = upsertRrset(rrsets, normalizeRecords(stampComments(rrset)))
+		}
+	}
+
+	// Like PowerDNS, SOA-EDIT-API is applied to a replaced SOA as well.
+	rrsets = editSOASerial(rrsets, zone.Name, zone.SOAEditAPI)
+
+	zone.Rrsets = rrsets
+	zone.Serial = soaSerial(rrsets, zone.Name)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.zones, zone.ID)
+	delete(s.cryptokeys, zone.ID)
+	delete(s.metadata, zone.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// secureZone generates a default key for a zone that has none, like PowerDNS
+// does when dnssec is enabled via the API. The caller must hold s.mu.
+func (s *Server) secureZone(zone *pdns_client.PDNSZone) {
+	if len(s.cryptokeys[zone.ID]) > 0 {
+		return
+	}
+	_, _ = s.addCryptokey(zone, pdns_client.Cryptokey{KeyType: "csk", Active: true, Published: true})
+}
+
+// normalizeMasters parses the masters of zone and stores them like PowerDNS
+// reports them, without the default port 53.
+func normalizeMasters(zone *pdns_client.PDNSZone) error {
+	for i, master := range zone.Masters {
+		host, port, err := net.SplitHostPort(master)
+		if err != nil {
+			host, port = strings.TrimSuffix(strings.TrimPrefix(master, "["), "]"), "53"
+		}
+
+		ip := net.ParseIP(host)
+		portNumber, err := strconv.ParseUint(port, 10, 16)
+		if ip == nil || err != nil || portNumber == 0 {
+			return fmt.Errorf("Master '%s' is not a valid IP address", master)
+		}
+
+		zone.Masters[i] = ip.String()
+		if portNumber != 53 {
+			zone.Masters[i] = net.JoinHostPort(ip.String(), strconv.FormatUint(portNumber, 10))
+		}
+	}
+	return nil
+}
+
+func validateDNSSecSettings(zone pdns_client.PDNSZone) error {
+	if zone.Nsec3Param != "" && !zone.Dnssec && !zone.Presigned {
+		return fmt.Errorf("NSEC3PARAMs provided for zone '%s', but zone is not DNSSEC secured.", zone.Name)
+	}
+	if zone.Nsec3Narrow && zone.Nsec3Param == "" {
+		return fmt.Errorf("nsec3narrow set for zone '%s', but zone has no NSEC3PARAMs.", zone.Name)
+	}
+	return nil
+}
+
+func validateRrset(zoneName string, rrset pdns_client.Rrset) error {
+	if !strings.HasSuffix(rrset.Name, ".") {
+		return fmt.Errorf("RRset %s IN %s: DNS Name '%s' is not canonical", rrset.Name, rrset.Type, rrset.Name)
+	}
+	if !inZone(rrset.Name, zoneName) {
+		return fmt.Errorf("RRset %s IN %s: Name is out of zone", rrset.Name, rrset.Type)
+	}
+	if rrset.Type == "" {
+		return fmt.Errorf("RRset %s: Type is missing", rrset.Name)
+	}
+
+	contents := make(map[string]bool, len(rrset.Records))
+	for _, record := range rrset.Records {
+		if strings.TrimSpace(record.Content) == "" {
+			return fmt.Errorf("RRset %s IN %s: Record content must not be empty", rrset.Name, rrset.Type)
+		}
+		if contents[record.Content] {
+			return fmt.Errorf("RRset %s IN %s has duplicate record content '%s'", rrset.Name, rrset.Type, record.Content)
+		}
+		contents[record.Content] = true
+	}
+
+	if (rrset.Type == "CNAME" || rrset.Type == "SOA") && len(rrset.Records) > 1 {
+		return fmt.Errorf("RRset %s IN %s has more than one record", rrset.Name, rrset.Type)
+	}
+
+	return nil
+}
+
+func inZone(name, zoneName string) bool {
+	name = strings.ToLower(name)
+	zoneName = strings.ToLower(zoneName)
+	return name == zoneName || strings.HasSuffix(name, "."+zoneName)
+}
+
+func upsertRrset(rrsets []pdns_client.Rrset, rrset pdns_client.Rrset) []pdns_client.Rrset {
+	idx := slices.IndexFunc(rrsets, func(item pdns_client.Rrset) bool {
+		return item.Name == rrset.Name && item.Type == rrset.Type
+	})
+	if idx >= 0 {
+		rrsets[idx] = rrset
+		return rrsets
+	}
+	return append(rrsets, rrset)
+}
+
+// stampComments sets the modification time of comments sent without one to
+// now, like PowerDNS does.
+func stampComments(rrset pdns_client.Rrset) pdns_client.Rrset {
+	rrset.Comments = slices.Clone(rrset.Comments)
+	for i := range rrset.Comments {
+		if rrset.Comments[i].ModifiedAt == 0 {
+			rrset.Comments[i].ModifiedAt = time.Now().Unix()
+		}
+	}
+	return rrset
+}
+
+// normalizeRecords rewrites the content of records like PowerDNS does, e.g.
+// it compresses IPv6 addresses and lower-cases hostnames.
+func normalizeRecords(rrset pdns_client.Rrset) pdns_client.Rrset {
+	rrset.Records = slices.Clone(rrset.Records)
+	for i, record := range rrset.Records {
+		fields := strings.Fields(record.Content)
+		switch rrset.Type {
+		case "A", "AAAA":
+			if addr, err := netip.ParseAddr(record.Content); err == nil {
+				rrset.Records[i].Content = addr.String()
+			}
+		case "CNAME", "NS", "PTR", "MX", "SRV":
+			if len(fields) > 0 {
+				fields[len(fields)-1] = strings.ToLower(fields[len(fields)-1])
+				rrset.Records[i].Content = strings.Join(fields, " ")
+			}
+		case "CAA":
+			if len(fields) == 3 {
+				fields[1] = strings.ToLower(fields[1])
+				rrset.Records[i].Content = strings.Join(fields, " ")
+			}
+		}
+	}
+	return rrset
+}
+
+// soaSerial extracts the serial from the apex SOA record, or returns 0 if the
+// zone has none.
+func soaSerial(rrsets []pdns_client.Rrset, zoneName string) int64 {
+	for _, rrset := range rrsets {
+		if rrset.Type != "SOA" || rrset.Name != zoneName || len(rrset.Records) == 0 {
+			continue
+		}
+		fields := strings.Fields(rrset.Records[0].Content)
+		if len(fields) < 3 {
+			return 0
+		}
+		serial, _ := strconv.ParseInt(fields[2], 10, 64)
+		return serial
+	}
+	return 0
+}
+
+// editSOASerial increases the serial of the apex SOA record according to the
+// SOA-EDIT-API kind of the zone. An empty kind leaves the serial untouched.
+func editSOASerial(rrsets []pdns_client.Rrset, zoneName string, soaEditAPI string) []pdns_client.Rrset {
+	if soaEditAPI == "" || soaEditAPI == "OFF" {
+		return rrsets
+	}
+
+	for i, rrset := range rrsets {
+		if rrset.Type != "SOA" || rrset.Name != zoneName || len(rrset.Records) == 0 {
+			continue
+		}
+		fields := strings.Fields(rrset.Records[0].Content)
+		if len(fields) < 3 {
+			return rrsets
+		}
+		serial, _ := strconv.ParseInt(fields[2], 10, 64)
+
+		switch soaEditAPI {
+		case "INCREASE":
+			serial++
+		case "EPOCH":
+			serial = max(time.Now().Unix(), serial+1)
+		default:
+			today, _ := strconv.ParseInt(time.Now().Format("20060102")+"01", 10, 64)
+			serial = max(today, serial+1)
+		}
+		fields[2] = strconv.FormatInt(serial, 10)
+
+		records := slices.Clone(rrset.Records)
+		records[0].Content = strings.Join(fields, " ")
+		rrsets[i].Records = records
+	}
+	return rrsets
+}
+
+func copyZone(zone pdns_client.PDNSZone) pdns_client.PDNSZone {
+	zone.Masters = slices.Clone(zone.Masters)
+	zone.Nameservers = slices.Clone(zone.Nameservers)
+	zone.MasterTsigKeyIDS = slices.Clone(zone.MasterTsigKeyIDS)
+	zone.SlaveTsigKeyIDS = slices.Clone(zone.SlaveTsigKeyIDS)
+	if zone.APIRectify != nil {
+		apiRectify := *zone.APIRectify
+		zone.APIRectify = &apiRectify
+	}
+	zone.Rrsets = slices.Clone(zone.Rrsets)
+	for i := range zone.Rrsets {
+		zone.Rrsets[i].Records = slices.Clone(zone.Rrsets[i].Records)
+		zone.Rrsets[i].Comments = slices.Clone(zone.Rrsets[i].Comments)
+	}
+	return zone
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,359 @@
+package pdnstest_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdnstest"
+)
+
+func newTestServer(t *testing.T) *pdnstest.Server {
+	t.Helper()
+
+	server := pdnstest.NewServer()
+	t.Cleanup(server.Close)
+
+	server.SetZone(pdns_client.PDNSZone{
+		Name: "example.com.",
+		Kind: "Native",
+		Rrsets: []pdns_client.Rrset{
+			{Name: "example.com.", Type: "SOA", TTL: 3600, Records: []pdns_client.Record{{Content: "ns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600"}}},
+			{Name: "www.example.com.", Type: "A", TTL: 300, Records: []pdns_client.Record{{Content: "192.0.2.1"}}},
+			{Name: "www.example.com.", Type: "AAAA", TTL: 300, Records: []pdns_client.Record{{Content: "2001:db8::1"}}},
+			{Name: "mail.example.com.", Type: "A", TTL: 300, Records: []pdns_client.Record{{Content: "192.0.2.2"}}},
+		},
+	})
+
+	return server
+}
+
+// request sends a request with the server's API key unless apiKey is set and
+// returns the status and body of the response.
+func request(t *testing.T, server *pdnstest.Server, method, path, body, apiKey string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if apiKey == "" {
+		apiKey = server.APIKey
+	}
+	req.Header.Set("X-API-Key", apiKey)
+
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(data)
+}
+
+func TestServer_authentication(t *testing.T) {
+	server := newTestServer(t)
+
+	status, _ := request(t, server, http.MethodGet, "/api/v1/servers/localhost/zones", "", "wrong-key")
+	if status != http.StatusUnauthorized {
+		t.Errorf("status with wrong API key = %d, want 401", status)
+	}
+
+	status, _ = request(t, server, http.MethodGet, "/api/v1/servers/localhost/zones", "", "")
+	if status != http.StatusOK {
+		t.Errorf("status with API key = %d, want 200", status)
+	}
+}
+
+func TestServer_notFound(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := map[string]struct {
+		path    string
+		message string
+	}{
+		"unknown server": {
+			path:    "/api/v1/servers/other/zones/example.com.",
+			message: "Not Found",
+		},
+		"unknown zone": {
+			path:    "/api/v1/servers/localhost/zones/example.org.",
+			message: "Could not find domain 'example.org.'",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status, body := request(t, server, http.MethodGet, test.path, "", "")
+			if status != http.StatusNotFound {
+				t.Errorf("status = %d, want 404", status)
+			}
+
+			var apiError struct {
+				Error string `json:"error"`
+			}
+			if err := json.Unmarshal([]byte(body), &apiError); err != nil {
+				t.Fatalf("decoding error body %q: %s", body, err)
+			}
+			if apiError.Error != test.message {
+				t.Errorf("error = %q, want %q", apiError.Error, test.message)
+			}
+		})
+	}
+}
+
+func TestServer_patchUnprocessable(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := map[string]struct {
+		body    string
+		message string
+	}{
+		"out of zone": {
+			body:    `{"rrsets": [{"name": "www.example.org.", "type": "A", "ttl": 300, "changetype": "REPLACE", "records": [{"content": "192.0.2.9"}]}]}`,
+			message: "out of zone",
+		},
+		"unknown changetype": {
+			body:    `{"rrsets": [{"name": "www.example.com.", "type": "A", "changetype": "UPSERT"}]}`,
+			message: "Changetype not understood",
+		},
+		"duplicate rrset": {
+			body:    `{"rrsets": [{"name": "www.example.com.", "type": "A", "changetype": "DELETE"}, {"name": "www.example.com.", "type": "A", "changetype": "DELETE"}]}`,
+			message: "Duplicate RRset",
+		},
+		"valid change before invalid one": {
+			body:    `{"rrsets": [{"name": "www.example.com.", "type": "A", "changetype": "DELETE"}, {"name": "www.example.org.", "type": "A", "changetype": "DELETE"}]}`,
+			message: "out of zone",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status, body := request(t, server, http.MethodPatch, "/api/v1/servers/localhost/zones/example.com.", test.body, "")
+			if status != http.StatusUnprocessableEntity {
+				t.Errorf("status = %d, want 422", status)
+			}
+			if !strings.Contains(body, test.message) {
+				t.Errorf("body = %q, want it to contain %q", body, test.message)
+			}
+
+			// A rejected PATCH must not apply any of its changes.
+			if _, ok := server.Rrset("example.com.", "www.example.com.", "A"); !ok {
+				t.Error("rejected PATCH deleted www.example.com. A")
+			}
+		})
+	}
+}
+
+func TestServer_rrsetFilter(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := map[string]struct {
+		query string
+		want  []string
+	}{
+		"name": {
+			query: "?rrset_name=www.example.com.",
+			want:  []string{"www.example.com./A", "www.example.com./AAAA"},
+		},
+		"name and type": {
+			query: "?rrset_name=www.example.com.&rrset_type=AAAA",
+			want:  []string{"www.example.com./AAAA"},
+		},
+		"unknown name": {
+			query: "?rrset_name=ftp.example.com.",
+			want:  []string{},
+		},
+		"without rrsets": {
+			query: "?rrsets=false",
+			want:  []string{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status, body := request(t, server, http.MethodGet, "/api/v1/servers/localhost/zones/example.com."+test.query, "", "")
+			if status != http.StatusOK {
+				t.Fatalf("status = %d, want 200", status)
+			}
+
+			var zone pdns_client.PDNSZone
+			if err := json.Unmarshal([]byte(body), &zone); err != nil {
+				t.Fatalf("decoding zone: %s", err)
+			}
+			got := make([]string, 0, len(zone.Rrsets))
+			for _, rrset := range zone.Rrsets {
+				got = append(got, rrset.Name+"/"+rrset.Type)
+			}
+			if strings.Join(got, " ") != strings.Join(test.want, " ") {
+				t.Errorf("rrsets = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestServer_failNext(t *testing.T) {
+	server := newTestServer(t)
+
+	server.FailNext(2, http.StatusServiceUnavailable)
+
+	for i, want := range []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK} {
+		status, _ := request(t, server, http.MethodGet, "/api/v1/servers/localhost/zones", "", "")
+		if status != want {
+			t.Errorf("request %d: status = %d, want %d", i+1, status, want)
+		}
+	}
+
+	// Failures are served before authentication, like a proxy in front of an
+	// unavailable server.
+	server.FailNext(1, http.StatusTooManyRequests)
+	if status, _ := request(t, server, http.MethodGet, "/api/v1/servers/localhost/zones", "", "wrong-key"); status != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want 429", status)
+	}
+
+	if got := server.Requests(); got != 4 {
+		t.Errorf("Requests() = %d, want 4", got)
+	}
+}
+
+func TestServer_patchComments(t *testing.T) {
+	server := newTestServer(t)
+	path := "/api/v1/servers/localhost/zones/example.com."
+
+	status, _ := request(t, server, http.MethodPatch, path, `{"rrsets": [{"name": "www.example.com.", "type": "A", "ttl": 300, "changetype": "REPLACE", "records": [{"content": "192.0.2.1"}], "comments": [{"content": "web"}]}]}`, "")
+	if status != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", status)
+	}
+
+	// Without the comments key the stored comments are kept.
+	status, _ = request(t, server, http.MethodPatch, path, `{"rrsets": [{"name": "www.example.com.", "type": "A", "ttl": 300, "changetype": "REPLACE", "records": [{"content": "192.0.2.3"}]}]}`, "")
+	if status != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", status)
+	}
+	rrset, _ := server.Rrset("example.com.", "www.example.com.", "A")
+	if len(rrset.Comments) != 1 || rrset.Comments[0].Content != "web" {
+		t.Errorf("comments after REPLACE without comments = %+v, want [web]", rrset.Comments)
+	}
+	if len(rrset.Records) != 1 || rrset.Records[0].Content != "192.0.2.3" {
+		t.Errorf("records = %+v, want [192.0.2.3]", rrset.Records)
+	}
+
+	// An empty list removes them.
+	status, _ = request(t, server, http.MethodPatch, path, `{"rrsets": [{"name": "www.example.com.", "type": "A", "ttl": 300, "changetype": "REPLACE", "records": [{"content": "192.0.2.3"}], "comments": []}]}`, "")
+	if status != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", status)
+	}
+	rrset, _ = server.Rrset("example.com.", "www.example.com.", "A")
+	if len(rrset.Comments) != 0 {
+		t.Errorf("comments after REPLACE with [] = %+v, want none", rrset.Comments)
+	}
+}
+
+func TestServer_patchBlankContent(t *testing.T) {
+	server := newTestServer(t)
+
+	status, body := request(t, server, http.MethodPatch, "/api/v1/servers/localhost/zones/example.com.", `{"rrsets": [{"name": "example.com.", "type": "MX", "ttl": 300, "changetype": "REPLACE", "records": [{"content": "   "}]}]}`, "")
+	if status != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want 422", status)
+	}
+	if !strings.Contains(body, "must not be empty") {
+		t.Errorf("body = %q, want an empty content error", body)
+	}
+}
+
+func TestServer_cryptokeys(t *testing.T) {
+	server := newTestServer(t)
+	const cryptokeys = "/api/v1/servers/localhost/zones/example.com./cryptokeys"
+
+	status, body := request(t, server, http.MethodPost, cryptokeys, `{"keytype": "ksk", "active": true}`, "")
+	if status != http.StatusCreated {
+		t.Fatalf("create status = %d, want 201: %s", status, body)
+	}
+	var created pdns_client.Cryptokey
+	if err := json.Unmarshal([]byte(body), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Algorithm != "ECDSAP256SHA256" || created.Bits != 256 {
+		t.Errorf("algorithm = %s/%d, want the default ECDSAP256SHA256/256", created.Algorithm, created.Bits)
+	}
+	if !created.Active || !created.Published {
+		t.Errorf("active = %t, published = %t, want both", created.Active, created.Published)
+	}
+	if created.PrivateKey == "" || created.DNSKey == "" || len(created.DS) != 2 {
+		t.Errorf("created key = %+v, want a private key, DNSKEY and two DS", created)
+	}
+	if zone, _ := server.Zone("example.com."); !zone.Dnssec {
+		t.Error("zone is not DNSSEC signed after adding a key")
+	}
+
+	key := cryptokeys + "/" + strconv.FormatInt(created.ID, 10)
+
+	// The list leaves out the private keys, a single key includes it.
+	_, body = request(t, server, http.MethodGet, cryptokeys, "", "")
+	var listed []pdns_client.Cryptokey
+	if err := json.Unmarshal([]byte(body), &listed); err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 1 || listed[0].PrivateKey != "" {
+		t.Errorf("listed keys = %+v, want one without private key", listed)
+	}
+	_, body = request(t, server, http.MethodGet, key, "", "")
+	var got pdns_client.Cryptokey
+	if err := json.Unmarshal([]byte(body), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.PrivateKey != created.PrivateKey {
+		t.Errorf("private key = %q, want %q", got.PrivateKey, created.PrivateKey)
+	}
+
+	if status, body := request(t, server, http.MethodPut, key, `{"active": false}`, ""); status != http.StatusNoContent {
+		t.Fatalf("update status = %d, want 204: %s", status, body)
+	}
+	if keys := server.Cryptokeys("example.com."); keys[0].Active || !keys[0].Published {
+		t.Errorf("after update active = %t, published = %t, want only published", keys[0].Active, keys[0].Published)
+	}
+
+	if status, _ := request(t, server, http.MethodDelete, key, "", ""); status != http.StatusNoContent {
+		t.Fatalf("delete status = %d, want 204", status)
+	}
+	if keys := server.Cryptokeys("example.com."); len(keys) != 0 {
+		t.Errorf("keys after delete = %+v, want none", keys)
+	}
+	if zone, _ := server.Zone("example.com."); zone.Dnssec {
+		t.Error("zone is still DNSSEC signed without keys")
+	}
+	if status, _ := request(t, server, http.MethodGet, key, "", ""); status != http.StatusNotFound {
+		t.Errorf("status of a deleted key = %d, want 404", status)
+	}
+}
+
+func TestServer_cryptokeyRejected(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := map[string]string{
+		"unknown key type":        `{"keytype": "other"}`,
+		"unknown algorithm":       `{"keytype": "zsk", "algorithm": "GOST"}`,
+		"bits of a fixed size":    `{"keytype": "zsk", "algorithm": "ED25519", "bits": 1024}`,
+		"bits with a private key": `{"keytype": "zsk", "bits": 2048, "privatekey": "Private-key-format: v1.2"}`,
+	}
+
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			status, _ := request(t, server, http.MethodPost, "/api/v1/servers/localhost/zones/example.com./cryptokeys", body, "")
+			if status != http.StatusUnprocessableEntity {
+				t.Errorf("status = %d, want 422", status)
+			}
+		})
+	}
+	if keys := server.Cryptokeys("example.com."); len(keys) != 0 {
+		t.Errorf("keys = %+v, want none", keys)
+	}
+}
@@ -0,0 +1,205 @@
+package pdnstest
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+var tsigAlgorithms = []string{"hmac-md5", "hmac-sha1", "hmac-sha224", "hmac-sha256", "hmac-sha384", "hmac-sha512"}
+
+// TSIGKey returns a copy of the stored TSIG key with the given id.
+func (s *Server) TSIGKey(keyID string) (pdns_client.TSIGKey, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tsigKey, ok := s.tsigKeys[keyID]
+	if !ok {
+		return pdns_client.TSIGKey{}, false
+	}
+	return *tsigKey, true
+}
+
+// tsigKeyID derives the id of a key from its name the way PowerDNS does for
+// plain names.
+func tsigKeyID(name string) string {
+	return strings.TrimSuffix(name, ".") + "."
+}
+
+// lookupTSIGKey returns the TSIG key addressed by the request path. The caller
+// must hold s.mu.
+func (s *Server) lookupTSIGKey(w http.ResponseWriter, r *http.Request) (*pdns_client.TSIGKey, bool) {
+	if !s.checkServer(w, r) {
+		return nil, false
+	}
+
+	tsigKey, ok := s.tsigKeys[r.PathValue("tsigkey_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return nil, false
+	}
+	return tsigKey, true
+}
+
+// validateTSIGKey checks the algorithm and key of tsigKey and generates a key
+// if none is set.
+func validateTSIGKey(tsigKey *pdns_client.TSIGKey) error {
+	if tsigKey.Algorithm == "" {
+		tsigKey.Algorithm = "hmac-md5"
+	}
+	tsigKey.Algorithm = strings.TrimSuffix(strings.ToLower(tsigKey.Algorithm), ".")
+	if !slices.Contains(tsigAlgorithms, tsigKey.Algorithm) {
+		return fmt.Errorf("Unknown TSIG algorithm: %s", tsigKey.Algorithm)
+	}
+
+	if tsigKey.Key == "" {
+		secret := sha256.Sum256([]byte(tsigKey.Name + "/" + tsigKey.Algorithm))
+		tsigKey.Key = base64.StdEncoding.EncodeToString(secret[:])
+	} else if _, err := base64.StdEncoding.DecodeString(tsigKey.Key); err != nil {
+		return fmt.Errorf("Can not base64 decode key content '%s'", tsigKey.Key)
+	}
+	return nil
+}
+
+func (s *Server) listTSIGKeys(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	tsigKeys := make([]pdns_client.TSIGKey, 0, len(s.tsigKeys))
+	for _, tsigKey := range s.tsigKeys {
+		listed := *tsigKey
+		listed.Key = ""
+		tsigKeys = append(tsigKeys, listed)
+	}
+	sort.Slice(tsigKeys, func(i, j int) bool { return tsigKeys[i].ID < tsigKeys[j].ID })
+
+	writeJSON(w, http.StatusOK, tsigKeys)
+}
+
+func (s *Server) getTSIGKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tsigKey, ok := s.lookupTSIGKey(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, *tsigKey)
+}
+
+func (s *Server) createTSIGKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	var tsigKey pdns_client.TSIGKey
+	if err := json.NewDecoder(r.Body).Decode(&tsigKey); err != nil {
+		writeError(w, http.StatusBadRequest, "Request body is not a valid JSON document: "+err.Error())
+		return
+	}
+
+	if tsigKey.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Key 'name' not present or not a String")
+		return
+	}
+	tsigKey.Name = strings.TrimSuffix(tsigKey.Name, ".")
+	tsigKey.ID = tsigKeyID(tsigKey.Name)
+	tsigKey.Type = "TSIGKey"
+	if _, exists := s.tsigKeys[tsigKey.ID]; exists {
+		writeError(w, http.StatusConflict, fmt.Sprintf("A TSIG key with the name '%s' already exists", tsigKey.Name))
+		return
+	}
+	if err := validateTSIGKey(&tsigKey); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	s.tsigKeys[tsigKey.ID] = &tsigKey
+
+	writeJSON(w, http.StatusCreated, tsigKey)
+}
+
+// updateTSIGKey changes the name, algorithm and key present in the request.
+// Renaming a key changes its id.
+func (s *Server) updateTSIGKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tsigKey, ok := s.lookupTSIGKey(w, r)
+	if !ok {
+		return
+	}
+
+	updated := *tsigKey
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+		writeError(w, http.StatusBadRequest, "Request body is not a valid JSON document: "+err.Error())
+		return
+	}
+
+	updated.Name = strings.TrimSuffix(updated.Name, ".")
+	updated.ID = tsigKeyID(updated.Name)
+	updated.Type = "TSIGKey"
+	if _, exists := s.tsigKeys[updated.ID]; exists && updated.ID != tsigKey.ID {
+		writeError(w, http.StatusConflict, fmt.Sprintf("A TSIG key with the name '%s' already exists", updated.Name))
+		return
+	}
+	if err := validateTSIGKey(&updated); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	delete(s.tsigKeys, tsigKey.ID)
+	s.tsigKeys[updated.ID] = &updated
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (s *Server) deleteTSIGKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tsigKey, ok := s.lookupTSIGKey(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.tsigKeys, tsigKey.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateZoneTSIGKeys checks that all TSIG keys referenced by zone exist. The
+// caller must hold s.mu.
+func (s *Server) validateZoneTSIGKeys(zone pdns_client.PDNSZone) error {
+	for _, keyID := range slices.Concat(zone.MasterTsigKeyIDS, zone.SlaveTsigKeyIDS) {
+		if _, ok := s.tsigKeys[tsigKeyID(keyID)]; !ok {
+			return fmt.Errorf("A TSIG key with the name '%s' does not exist", strings.TrimSuffix(keyID, "."))
+		}
+	}
+	return nil
+}
+
+// normalizeZoneTSIGKeys turns the key names referenced by zone into ids, as
+// PowerDNS reports them.
+func normalizeZoneTSIGKeys(zone *pdns_client.PDNSZone) {
+	for _, keyIDs := range [][]string{zone.MasterTsigKeyIDS, zone.SlaveTsigKeyIDS} {
+		for i, keyID := range keyIDs {
+			keyIDs[i] = tsigKeyID(keyID)
+		}
+	}
+}
@@ -1,11 +1,18 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
 )
 
@@ -18,6 +25,73 @@ func fqdn(name, zone string) string {
 	return name + "." + zone
 }
 
+// relativeName strips the zone from a fully qualified name, the inverse of
+// fqdn. The apex has no relative form and is returned fully qualified.
+func relativeName(name, zone string) string {
+	if strings.EqualFold(name, zone) {
+		return name
+	}
+	if len(name) > len(zone) && strings.EqualFold(name[len(name)-len(zone)-1:], "."+zone) {
+		return name[:len(name)-len(zone)-1]
+	}
+	return name
+}
+
+// longestMatchingZone returns the most specific of zones that name belongs to,
+// or an empty string if none does.
+func longestMatchingZone(name string, zones []string) string {
+	best := ""
+	for _, zone := range zones {
+		inZone := strings.EqualFold(name, zone) || relativeName(name, zone) != name
+		if inZone && len(zone) > len(best) {
+			best = zone
+		}
+	}
+	return best
+}
+
+// canonicalMaster returns the form PowerDNS reports a master address in: the
+// IP address, followed by the port unless it is the default port 53. It
+// reports false if master is not an IP address with an optional port.
+func canonicalMaster(master string) (string, bool) {
+	host, port, err := net.SplitHostPort(master)
+	if err != nil {
+		host, port = strings.TrimSuffix(strings.TrimPrefix(master, "["), "]"), "53"
+	}
+
+	ip := net.ParseIP(host)
+	portNumber, err := strconv.ParseUint(port, 10, 16)
+	if ip == nil || err != nil || portNumber == 0 {
+		return "", false
+	}
+
+	if portNumber == 53 {
+		return ip.String(), true
+	}
+	return net.JoinHostPort(ip.String(), strconv.FormatUint(portNumber, 10)), true
+}
+
+// stringsFromList returns the elements of a list of strings. Null and unknown
+// lists yield an empty, non-nil slice, so that PowerDNS receives `[]` and
+// clears the property.
+func stringsFromList(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	values := make([]string, 0, len(list.Elements()))
+	if list.IsNull() || list.IsUnknown() {
+		return values, nil
+	}
+	diags := list.ElementsAs(ctx, &values, false)
+	return values, diags
+}
+
+// listFromStrings is the inverse of stringsFromList, an empty slice yields a
+// null list to match an unset optional attribute.
+func listFromStrings(ctx context.Context, values []string) (types.List, diag.Diagnostics) {
+	if len(values) == 0 {
+		return types.ListNull(types.StringType), nil
+	}
+	return types.ListValueFrom(ctx, types.StringType, values)
+}
+
 // handleClientError translates a pdns_client error into diagnostics. It returns
 // true when err is non-nil (and a diagnostic was added), so callers can early
 // return with `if handleClientError(&resp.Diagnostics, err) { return }`.
@@ -27,15 +101,112 @@ func handleClientError(diags *diag.Diagnostics, err error) bool {
 	}
 
 	var unauthorizedError *pdns_client.PDNSUnauthorizedError
-	var notFoundError *pdns_client.PDNSZoneNotFoundError
+	var zoneNotFoundError *pdns_client.PDNSZoneNotFoundError
+	var rrsetNotFoundError *pdns_client.PDNSRrsetNotFoundError
+	var cryptokeyNotFoundError *pdns_client.PDNSCryptokeyNotFoundError
+	var tsigKeyNotFoundError *pdns_client.PDNSTSIGKeyNotFoundError
+	var serverNotFoundError *pdns_client.PDNSServerNotFoundError
+	var apiError *pdns_client.PDNSAPIError
 	switch {
 	case errors.As(err, &unauthorizedError):
 		diags.AddError("Authorization Error", "Not authorized to access pdns api")
-	case errors.As(err, &notFoundError):
-		diags.AddError("Zone not found", notFoundError.Error())
+	case errors.As(err, &zoneNotFoundError):
+		diags.AddError("Zone not found", zoneNotFoundError.Error())
+	case errors.As(err, &rrsetNotFoundError):
+		diags.AddError("Record not found", rrsetNotFoundError.Error())
+	case errors.As(err, &cryptokeyNotFoundError):
+		diags.AddError("Cryptokey not found", cryptokeyNotFoundError.Error())
+	case errors.As(err, &tsigKeyNotFoundError):
+		diags.AddError("TSIG key not found", tsigKeyNotFoundError.Error())
+	case errors.As(err, &serverNotFoundError):
+		diags.AddError("Server not found", serverNotFoundError.Error()+". Check the server_id and endpoint of the provider")
+	case errors.As(err, &apiError):
+		addAPIErrorDiagnostic(diags, apiError, zoneErrorAttributes)
 	default:
 		diags.AddError("Client Error", fmt.Sprintf("Unable to do http request to pdns API, got error: %s", err))
 	}
 
 	return true
 }
+
+// handleRecordClientError is handleClientError for rrset changes made by the
+// record resources. Rejections that PowerDNS reports for an rrset are attached
+// to the offending attribute found in attributes.
+func handleRecordClientError(diags *diag.Diagnostics, err error, attributes []apiErrorAttribute) bool {
+	var apiError *pdns_client.PDNSAPIError
+	if errors.As(err, &apiError) {
+		addAPIErrorDiagnostic(diags, apiError, attributes)
+		return true
+	}
+
+	return handleClientError(diags, err)
+}
+
+// apiErrorAttribute maps a PowerDNS error message to the attribute it is about.
+type apiErrorAttribute struct {
+	message   *regexp.Regexp
+	attribute string
+}
+
+var zoneErrorAttributes = []apiErrorAttribute{
+	{regexp.MustCompile(`(?i)\bkind\b`), "kind"},
+	{regexp.MustCompile(`(?i)\bmasters?\b`), "masters"},
+	{regexp.MustCompile(`(?i)\bnameservers?\b`), "nameservers"},
+	{regexp.MustCompile(`(?i)\bnsec3narrow\b`), "nsec3narrow"},
+	{regexp.MustCompile(`(?i)\bnsec3params?\b`), "nsec3param"},
+}
+
+// recordErrorAttributes returns the attributes of pdns_record that rrset
+// rejections are about. Errors in the content of the records are attached to
+// recordsAttribute, i.e. to whichever of `records` and `record` is configured.
+func recordErrorAttributes(recordsAttribute string) []apiErrorAttribute {
+	return []apiErrorAttribute{
+		{regexp.MustCompile(`(?i)has more than one record`), recordsAttribute},
+		{regexp.MustCompile(`(?i)duplicate record`), recordsAttribute},
+		{regexp.MustCompile(`(?i)(parsing|invalid) record content|record content must`), recordsAttribute},
+		{regexp.MustCompile(`(?i)conflicts with (pre-existing|another) rrset`), "type"},
+		{regexp.MustCompile(`(?i)\bttl\b`), "ttl"},
+		{regexp.MustCompile(`(?i)out of zone|is not canonical`), "name"},
+	}
+}
+
+// typedRecordErrorAttributes are the attributes of the typed record resources
+// that rrset rejections are about. Their type is fixed by the resource, so a
+// conflicting rrset is attached to the name.
+var typedRecordErrorAttributes = []apiErrorAttribute{
+	{regexp.MustCompile(`(?i)has more than one record`), "records"},
+	{regexp.MustCompile(`(?i)duplicate record`), "records"},
+	{regexp.MustCompile(`(?i)(parsing|invalid) record content|record content must`), "records"},
+	{regexp.MustCompile(`(?i)conflicts with (pre-existing|another) rrset`), "name"},
+	{regexp.MustCompile(`(?i)\bttl\b`), "ttl"},
+	{regexp.MustCompile(`(?i)out of zone|is not canonical`), "name"},
+}
+
+func addAPIErrorDiagnostic(diags *diag.Diagnostics, apiError *pdns_client.PDNSAPIError, attributes []apiErrorAttribute) {
+	var summary string
+	switch {
+	case apiError.StatusCode == http.StatusBadRequest || apiError.StatusCode == http.StatusUnprocessableEntity:
+		summary = "Rejected by PowerDNS"
+	case apiError.StatusCode == http.StatusConflict:
+		summary = "Conflict in PowerDNS"
+	case apiError.StatusCode >= http.StatusInternalServerError:
+		summary = "PowerDNS Server Error"
+	default:
+		summary = "PowerDNS API Error"
+	}
+
+	details := apiError.Details()
+	if details == "" {
+		details = http.StatusText(apiError.StatusCode)
+	}
+	detail := fmt.Sprintf("%s\n\n(HTTP %d on %s %s)", details, apiError.StatusCode, apiError.Method, apiError.Path)
+
+	for _, attribute := range attributes {
+		if attribute.message.MatchString(details) {
+			diags.AddAttributeError(path.Root(attribute.attribute), summary, detail)
+			return
+		}
+	}
+
+	diags.AddError(summary, detail)
+}
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdnstest"
+)
+
+func TestLongestMatchingZone(t *testing.T) {
+	zones := []string{"example.com.", "sub.example.com.", "com.", "example.org."}
+
+	tests := map[string]struct {
+		name string
+		want string
+	}{
+		"apex":                     {name: "example.com.", want: "example.com."},
+		"name in zone":             {name: "www.example.com.", want: "example.com."},
+		"longest zone wins":        {name: "www.sub.example.com.", want: "sub.example.com."},
+		"apex of the longer zone":  {name: "sub.example.com.", want: "sub.example.com."},
+		"parent zone":              {name: "other.com.", want: "com."},
+		"case insensitive":         {name: "WWW.Sub.Example.COM.", want: "sub.example.com."},
+		"label suffix is no match": {name: "www.myexample.org.", want: ""},
+		"no zone":                  {name: "www.example.net.", want: ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := longestMatchingZone(test.name, zones); got != test.want {
+				t.Errorf("longestMatchingZone(%q) = %q, want %q", test.name, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalMaster(t *testing.T) {
+	tests := map[string]struct {
+		master string
+		want   string
+		valid  bool
+	}{
+		"IPv4":                          {master: "192.0.2.1", want: "192.0.2.1", valid: true},
+		"IPv4 with port":                {master: "192.0.2.1:5300", want: "192.0.2.1:5300", valid: true},
+		"IPv4 with port 53":             {master: "192.0.2.1:53", want: "192.0.2.1", valid: true},
+		"IPv6":                          {master: "2001:db8::1", want: "2001:db8::1", valid: true},
+		"bracketed IPv6":                {master: "[2001:db8::1]", want: "2001:db8::1", valid: true},
+		"bracketed IPv6 with port":      {master: "[2001:db8::1]:5300", want: "[2001:db8::1]:5300", valid: true},
+		"bracketed IPv6 with port 53":   {master: "[2001:db8::1]:53", want: "2001:db8::1", valid: true},
+		"IPv6 is shortened":             {master: "2001:0db8:0000::0001", want: "2001:db8::1", valid: true},
+		"port 0":                        {master: "192.0.2.1:0", valid: false},
+		"port out of range":             {master: "192.0.2.1:65536", valid: false},
+		"bracketed IPv6 with port 0":    {master: "[2001:db8::1]:0", valid: false},
+		"hostname":                      {master: "ns1.example.com", valid: false},
+		"hostname with port":            {master: "ns1.example.com:53", valid: false},
+		"empty":                         {master: "", valid: false},
+		"unbracketed IPv6 with a colon": {master: "2001:db8::1:", valid: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := canonicalMaster(test.master)
+			if ok != test.valid {
+				t.Fatalf("canonicalMaster(%q) valid = %t, want %t", test.master, ok, test.valid)
+			}
+			if got != test.want {
+				t.Errorf("canonicalMaster(%q) = %q, want %q", test.master, got, test.want)
+			}
+		})
+	}
+}
+
+func TestResolveRecordName(t *testing.T) {
+	server := pdnstest.NewServer()
+	t.Cleanup(server.Close)
+	server.SetZone(pdns_client.PDNSZone{Name: "example.com.", Kind: "Native"})
+	server.SetZone(pdns_client.PDNSZone{Name: "sub.example.com.", Kind: "Native"})
+
+	providerData := &PDNSProviderData{
+		pdnsClient: pdns_client.NewPDNSClient(server.Client(), server.URL, server.ServerID, server.APIKey),
+	}
+
+	tests := map[string]struct {
+		id        string
+		wantZone  string
+		wantName  string
+		wantError bool
+	}{
+		"zone and relative name":           {id: "example.com.:www", wantZone: "example.com.", wantName: "www"},
+		"zone without trailing dot":        {id: "example.com:www", wantZone: "example.com.", wantName: "www"},
+		"zone and fully qualified name":    {id: "example.com.:www.example.com.", wantZone: "example.com.", wantName: "www.example.com."},
+		"fully qualified name":             {id: "www.example.com.", wantZone: "example.com.", wantName: "www"},
+		"name without trailing dot":        {id: "www.example.com", wantZone: "example.com.", wantName: "www"},
+		"name in the longest zone":         {id: "a.b.sub.example.com.", wantZone: "sub.example.com.", wantName: "a.b"},
+		"apex":                             {id: "sub.example.com.", wantZone: "sub.example.com.", wantName: "sub.example.com."},
+		"empty zone":                       {id: ":www"},
+		"empty name":                       {id: "example.com.:"},
+		"too many separators":              {id: "example.com.:www:A"},
+		"empty id":                         {id: ""},
+		"name outside of the server zones": {id: "www.example.org.", wantError: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			zone, recordName, diags := resolveRecordName(context.Background(), providerData, test.id)
+			if diags.HasError() != test.wantError {
+				t.Fatalf("resolveRecordName(%q) diagnostics = %v, want error %t", test.id, diags, test.wantError)
+			}
+			if zone != test.wantZone || recordName != test.wantName {
+				t.Errorf("resolveRecordName(%q) = %q, %q, want %q, %q", test.id, zone, recordName, test.wantZone, test.wantName)
+			}
+		})
+	}
+}
+
+func TestAddAPIErrorDiagnostic_recordAttributes(t *testing.T) {
+	tests := map[string]struct {
+		message          string
+		recordsAttribute string
+		want             path.Path
+	}{
+		"content error with records": {
+			message:          "Record www.example.com./A '10.0.0': Parsing record content (try 'pdnsutil check-zone'): unable to parse IP address",
+			recordsAttribute: "records",
+			want:             path.Root("records"),
+		},
+		"content error with record": {
+			message:          "Record www.example.com./A '10.0.0': Parsing record content (try 'pdnsutil check-zone'): unable to parse IP address",
+			recordsAttribute: "record",
+			want:             path.Root("record"),
+		},
+		"duplicate record with record": {
+			message:          "Duplicate record in RRset www.example.com. IN A with content \"10.0.0.1\"",
+			recordsAttribute: "record",
+			want:             path.Root("record"),
+		},
+		"conflicting rrset": {
+			message:          "RRset www.example.com. IN A: Conflicts with pre-existing RRset",
+			recordsAttribute: "record",
+			want:             path.Root("type"),
+		},
+		"out of zone": {
+			message:          "RRset www.example.org. IN A: Name is out of zone",
+			recordsAttribute: "records",
+			want:             path.Root("name"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			apiError := &pdns_client.PDNSAPIError{StatusCode: http.StatusUnprocessableEntity, Method: http.MethodPatch, Path: "zones/example.com.", Message: test.message}
+			addAPIErrorDiagnostic(&diags, apiError, recordErrorAttributes(test.recordsAttribute))
+
+			if len(diags) != 1 {
+				t.Fatalf("diagnostics = %v, want one", diags)
+			}
+			withPath, ok := diags[0].(diag.DiagnosticWithPath)
+			if !ok {
+				t.Fatalf("diagnostic %v has no attribute path, want %s", diags[0], test.want)
+			}
+			if !withPath.Path().Equal(test.want) {
+				t.Errorf("attribute path = %s, want %s", withPath.Path(), test.want)
+			}
+		})
+	}
+}
+
+func TestAddAPIErrorDiagnostic_typedRecordAttributes(t *testing.T) {
+	tests := map[string]struct {
+		message string
+		want    path.Path
+	}{
+		"content error": {
+			message: "Record mail.example.com./MX '10': Parsing record content (try 'pdnsutil check-zone'): Not enough input for MX",
+			want:    path.Root("records"),
+		},
+		"conflicting rrset": {
+			message: "RRset mail.example.com. IN MX: Conflicts with pre-existing RRset",
+			want:    path.Root("name"),
+		},
+		"out of zone": {
+			message: "RRset mail.example.org. IN MX: Name is out of zone",
+			want:    path.Root("name"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			apiError := &pdns_client.PDNSAPIError{StatusCode: http.StatusUnprocessableEntity, Method: http.MethodPatch, Path: "zones/example.com.", Message: test.message}
+			addAPIErrorDiagnostic(&diags, apiError, typedRecordErrorAttributes)
+
+			if len(diags) != 1 {
+				t.Fatalf("diagnostics = %v, want one", diags)
+			}
+			withPath, ok := diags[0].(diag.DiagnosticWithPath)
+			if !ok {
+				t.Fatalf("diagnostic %v has no attribute path, want %s", diags[0], test.want)
+			}
+			if !withPath.Path().Equal(test.want) {
+				t.Errorf("attribute path = %s, want %s", withPath.Path(), test.want)
+			}
+		})
+	}
+}
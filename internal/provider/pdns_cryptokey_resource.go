@@ -0,0 +1,325 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/samber/lo"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+var (
+	_ resource.Resource                = &CryptokeyResource{}
+	_ resource.ResourceWithImportState = &CryptokeyResource{}
+	_ resource.ResourceWithConfigure   = &CryptokeyResource{}
+)
+
+// dnssecAlgorithms are the algorithm names PowerDNS reports for its keys.
+var dnssecAlgorithms = []string{
+	"RSASHA1",
+	"RSASHA1-NSEC3-SHA1",
+	"RSASHA256",
+	"RSASHA512",
+	"ECDSAP256SHA256",
+	"ECDSAP384SHA384",
+	"ED25519",
+	"ED448",
+}
+
+func NewCryptokeyResource() resource.Resource {
+	return &CryptokeyResource{}
+}
+
+type CryptokeyResource struct {
+	providerData *PDNSProviderData
+}
+
+type CryptokeyResourceModel struct {
+	Zone       types.String `tfsdk:"zone"`
+	KeyID      types.Int64  `tfsdk:"key_id"`
+	KeyType    types.String `tfsdk:"key_type"`
+	Algorithm  types.String `tfsdk:"algorithm"`
+	Bits       types.Int64  `tfsdk:"bits"`
+	Active     types.Bool   `tfsdk:"active"`
+	Published  types.Bool   `tfsdk:"published"`
+	PrivateKey types.String `tfsdk:"private_key"`
+	DNSKey     types.String `tfsdk:"dnskey"`
+	DS         types.List   `tfsdk:"ds"`
+}
+
+func (r *CryptokeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cryptokey"
+}
+
+func (r *CryptokeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a DNSSEC key (cryptokey) of a PowerDNS zone. PowerDNS signs a zone as soon as it has an active key, so set `dnssec = true` on the `pdns_zone` as well.",
+
+		Attributes: map[string]schema.Attribute{
+			"zone": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone the key belongs to. The name must end with a dot `.`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`\.$`), "Name must end with a dot"),
+				},
+			},
+			"key_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The id PowerDNS assigned to the key",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The role of the key. One of `ksk`, `zsk` or `csk`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("ksk", "zsk", "csk"),
+				},
+			},
+			"algorithm": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The DNSSEC algorithm of the key, e.g. `ECDSAP256SHA256` or `ED25519`. Defaults to the `default-ksk-algorithm` or `default-zsk-algorithm` of the server.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(dnssecAlgorithms...),
+				},
+			},
+			"bits": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The size of the key in bits. Only meaningful for RSA algorithms, the other algorithms have a fixed size. Must not be set together with `private_key`.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.ConflictsWith(path.MatchRoot("private_key")),
+				},
+			},
+			"active": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the key is used to sign the zone. Defaults to `false`.",
+			},
+			"published": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the DNSKEY record of the key is published in the zone. Defaults to `true`.",
+			},
+			"private_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "An existing private key in ISC format to import instead of generating a new one.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"dnskey": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The DNSKEY record content of the key",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ds": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The DS record contents to publish in the parent zone, one per digest type. Empty for a `zsk`.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CryptokeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*PDNSProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError("Parse Error", "Failed to parse provider data")
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *CryptokeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CryptokeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cryptokey, err := r.providerData.pdnsClient.CreateCryptokey(ctx, data.Zone.ValueString(), pdns_client.Cryptokey{
+		KeyType:    data.KeyType.ValueString(),
+		Active:     data.Active.ValueBool(),
+		Published:  data.Published.ValueBool(),
+		Algorithm:  data.Algorithm.ValueString(),
+		Bits:       data.Bits.ValueInt64(),
+		PrivateKey: data.PrivateKey.ValueString(),
+	})
+	if handleClientError(&resp.Diagnostics, err) {
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromCryptokey(ctx, cryptokey)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CryptokeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CryptokeyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cryptokey, err := r.providerData.pdnsClient.GetCryptokey(ctx, data.Zone.ValueString(), data.KeyID.ValueInt64())
+
+	var zoneNotFoundError *pdns_client.PDNSZoneNotFoundError
+	var cryptokeyNotFoundError *pdns_client.PDNSCryptokeyNotFoundError
+	if errors.As(err, &zoneNotFoundError) || errors.As(err, &cryptokeyNotFoundError) {
+		tflog.Warn(ctx, "Cryptokey was deleted outside of Terraform, removing it from state", map[string]any{
+			"zone":   data.Zone.ValueString(),
+			"key_id": data.KeyID.ValueInt64(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if handleClientError(&resp.Diagnostics, err) {
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromCryptokey(ctx, cryptokey)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CryptokeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CryptokeyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Everything but active and published requires a replacement.
+	err := r.providerData.pdnsClient.SetCryptokeyState(
+		ctx,
+		plan.Zone.ValueString(),
+		plan.KeyID.ValueInt64(),
+		plan.Active.ValueBool(),
+		plan.Published.ValueBool(),
+	)
+	if handleClientError(&resp.Diagnostics, err) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CryptokeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CryptokeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.providerData.pdnsClient.DeleteCryptokey(ctx, data.Zone.ValueString(), data.KeyID.ValueInt64())
+	handleClientError(&resp.Diagnostics, err)
+}
+
+// ImportState accepts `<zone>:<key_id>`, e.g. `example.com.:3`. An imported
+// key keeps `private_key` unset even though PowerDNS would return it.
+func (r *CryptokeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	zone, rawKeyID, found := strings.Cut(req.ID, ":")
+	keyID, err := strconv.ParseInt(rawKeyID, 10, 64)
+	if !found || zone == "" || err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID like 'example.com.:3', got '%s'", req.ID),
+		)
+		return
+	}
+
+	if !strings.HasSuffix(zone, ".") {
+		zone += "."
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), zone)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key_id"), keyID)...)
+}
+
+// fromCryptokey copies the attributes PowerDNS reports for a key into the
+// model. The private key is left untouched so that generated keys never end up
+// in the state.
+func (m *CryptokeyResourceModel) fromCryptokey(ctx context.Context, cryptokey pdns_client.Cryptokey) diag.Diagnostics {
+	ds, diags := types.ListValueFrom(ctx, types.StringType, lo.Ternary(cryptokey.DS == nil, []string{}, cryptokey.DS))
+	if diags.HasError() {
+		return diags
+	}
+
+	m.KeyID = types.Int64Value(cryptokey.ID)
+	m.KeyType = types.StringValue(cryptokey.KeyType)
+	m.Algorithm = types.StringValue(cryptokey.Algorithm)
+	m.Bits = types.Int64Value(cryptokey.Bits)
+	m.Active = types.BoolValue(cryptokey.Active)
+	m.Published = types.BoolValue(cryptokey.Published)
+	m.DNSKey = types.StringValue(cryptokey.DNSKey)
+	m.DS = ds
+
+	return diags
+}
@@ -0,0 +1,123 @@
+package provider_test
+
+import (
+	"strconv"
+	"testing"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/acctest"
+)
+
+func TestAccCryptokeyResource(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	if _, err := d.Create("pdns_zone", testZoneConfig(10800)); err != nil {
+		t.Fatalf("create zone: %s", err)
+	}
+
+	config := map[string]any{"zone": "example.com.", "key_type": "ksk", "active": true}
+	state, err := d.Create("pdns_cryptokey", config)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	keys := d.Server.Cryptokeys("example.com.")
+	if len(keys) != 1 {
+		t.Fatalf("keys = %+v, want one", keys)
+	}
+	if got := acctest.Int64Attribute(state, "key_id"); got != keys[0].ID {
+		t.Errorf("key_id = %d, want %d", got, keys[0].ID)
+	}
+	if !keys[0].Active || !keys[0].Published {
+		t.Errorf("active = %t, published = %t, want both", keys[0].Active, keys[0].Published)
+	}
+	if got := acctest.StringAttribute(state, "algorithm"); got != keys[0].Algorithm {
+		t.Errorf("algorithm = %q, want the server default %q", got, keys[0].Algorithm)
+	}
+	if got := acctest.StringAttribute(state, "dnskey"); got != keys[0].DNSKey {
+		t.Errorf("dnskey = %q, want %q", got, keys[0].DNSKey)
+	}
+	if got := acctest.StringsAttribute(state, "ds"); len(got) != 2 {
+		t.Errorf("ds = %q, want one per digest type", got)
+	}
+	if !acctest.Attribute(state, "private_key").IsNull() {
+		t.Error("the generated private key ended up in state")
+	}
+
+	read, err := d.Read("pdns_cryptokey", state)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if !read.Equal(state) {
+		t.Errorf("read drifted from state:\n got: %s\nwant: %s", read, state)
+	}
+
+	// Deactivating and unpublishing a key is done in place.
+	config = map[string]any{"zone": "example.com.", "key_type": "ksk", "active": false, "published": false}
+	updated, err := d.Update("pdns_cryptokey", state, config)
+	if err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	keys = d.Server.Cryptokeys("example.com.")
+	if len(keys) != 1 || keys[0].Active || keys[0].Published {
+		t.Errorf("keys after update = %+v, want the same key inactive and unpublished", keys)
+	}
+	if got := acctest.Int64Attribute(updated, "key_id"); got != keys[0].ID {
+		t.Errorf("key_id after update = %d, want %d", got, keys[0].ID)
+	}
+
+	imported, err := d.Import("pdns_cryptokey", "example.com.:"+strconv.FormatInt(keys[0].ID, 10))
+	if err != nil {
+		t.Fatalf("import: %s", err)
+	}
+	planned, err := d.Plan("pdns_cryptokey", imported, config)
+	if err != nil {
+		t.Fatalf("plan after import: %s", err)
+	}
+	if !planned.Equal(imported) {
+		t.Errorf("plan after import is not empty:\n got: %s\nwant: %s", planned, imported)
+	}
+
+	if err := d.Destroy("pdns_cryptokey", updated); err != nil {
+		t.Fatalf("destroy: %s", err)
+	}
+	if keys := d.Server.Cryptokeys("example.com."); len(keys) != 0 {
+		t.Errorf("keys after destroy = %+v, want none", keys)
+	}
+
+	read, err = d.Read("pdns_cryptokey", updated)
+	if err != nil {
+		t.Fatalf("read after destroy: %s", err)
+	}
+	if !read.IsNull() {
+		t.Error("a deleted key was kept in state")
+	}
+}
+
+func TestAccCryptokeyResource_zsk(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	if _, err := d.Create("pdns_zone", testZoneConfig(10800)); err != nil {
+		t.Fatalf("create zone: %s", err)
+	}
+
+	state, err := d.Create("pdns_cryptokey", map[string]any{"zone": "example.com.", "key_type": "zsk", "algorithm": "RSASHA256", "bits": 2048})
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	if got := acctest.StringsAttribute(state, "ds"); got == nil || len(got) != 0 {
+		t.Errorf("ds of a zsk = %q, want an empty list", got)
+	}
+	if got := acctest.Int64Attribute(state, "bits"); got != 2048 {
+		t.Errorf("bits = %d, want 2048", got)
+	}
+}
+
+func TestAccCryptokeyResource_invalidImportID(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	for _, id := range []string{"example.com.", "example.com.:ksk", ":3"} {
+		if _, err := d.Import("pdns_cryptokey", id); err == nil {
+			t.Errorf("importing %q succeeded, want error", id)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+var (
+	_ datasource.DataSource              = &RecordDataSource{}
+	_ datasource.DataSourceWithConfigure = &RecordDataSource{}
+)
+
+func NewRecordDataSource() datasource.DataSource {
+	return &RecordDataSource{}
+}
+
+type RecordDataSource struct {
+	providerData *PDNSProviderData
+}
+
+type RecordDataSourceModel struct {
+	Zone     types.String `tfsdk:"zone"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	FQDN     types.String `tfsdk:"fqdn"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Contents types.List   `tfsdk:"contents"`
+	Records  types.List   `tfsdk:"records"`
+	Comments types.List   `tfsdk:"comments"`
+}
+
+func (d *RecordDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record"
+}
+
+func (d *RecordDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing DNS record (rrset) of a PowerDNS zone without managing it. Fails if the rrset does not exist.",
+		Attributes: map[string]schema.Attribute{
+			"zone": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone containing the record. The name must end with a dot `.`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`\.$`), "Name must end with a dot"),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "LValue of the record (name). Suffixed with the zone name unless ending with an explicit `.`.",
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Type of the record e.g. A, AAAA or CNAME",
+			},
+			"fqdn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The fully qualified name of the record",
+			},
+			"ttl": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "TTL of the record",
+			},
+			"contents": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Contents of the enabled records, for convenient use in other resources.",
+			},
+			"records":  recordsDataSourceAttribute(),
+			"comments": commentsDataSourceAttribute(),
+		},
+	}
+}
+
+func (d *RecordDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*PDNSProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError("Parse Error", "Failed to parse provider data")
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *RecordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RecordDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expandedName := fqdn(data.Name.ValueString(), data.Zone.ValueString())
+	zone, err := d.providerData.pdnsClient.GetZone(ctx, data.Zone.ValueString(), true, expandedName)
+	if handleClientError(&resp.Diagnostics, err) {
+		return
+	}
+
+	rrset, isFound := lo.Find(zone.Rrsets, func(item pdns_client.Rrset) bool {
+		return item.Name == expandedName && strings.EqualFold(item.Type, data.Type.ValueString())
+	})
+
+	if !isFound {
+		handleClientError(&resp.Diagnostics, &pdns_client.PDNSRrsetNotFoundError{
+			ZoneID: data.Zone.ValueString(),
+			Name:   expandedName,
+			Type:   strings.ToUpper(data.Type.ValueString()),
+		})
+		return
+	}
+
+	records, diags := recordsToList(ctx, rrset.Records)
+	resp.Diagnostics.Append(diags...)
+
+	comments, diags := commentsToList(ctx, rrset.Comments)
+	resp.Diagnostics.Append(diags...)
+
+	contents, diags := types.ListValueFrom(ctx, types.StringType, lo.FilterMap(rrset.Records, func(item pdns_client.Record, index int) (string, bool) {
+		return item.Content, !item.Disabled
+	}))
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.FQDN = types.StringValue(expandedName)
+	data.TTL = types.Int64Value(rrset.TTL)
+	data.Contents = contents
+	data.Records = records
+	data.Comments = comments
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,32 @@
+package provider_test
+
+import (
+	"testing"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/acctest"
+)
+
+func TestAccRecordDataSource(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	if _, err := d.Create("pdns_zone", testZoneConfig(10800)); err != nil {
+		t.Fatalf("create zone: %s", err)
+	}
+	if _, err := d.Create("pdns_record", testRecordConfig("10.0.0.1")); err != nil {
+		t.Fatalf("create record: %s", err)
+	}
+
+	for _, rrType := range []string{"A", "a"} {
+		state, err := d.ReadDataSource("pdns_record", map[string]any{"zone": "example.com.", "name": "www", "type": rrType})
+		if err != nil {
+			t.Fatalf("read type %q: %s", rrType, err)
+		}
+		if got := acctest.StringAttribute(state, "fqdn"); got != "www.example.com." {
+			t.Errorf("type %q: fqdn = %q, want www.example.com.", rrType, got)
+		}
+	}
+
+	if _, err := d.ReadDataSource("pdns_record", map[string]any{"zone": "example.com.", "name": "www", "type": "aaaa"}); err == nil {
+		t.Error("reading a missing rrset succeeded, want error")
+	}
+}
@@ -2,28 +2,35 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/samber/lo"
 	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
 )
 
 var (
-	_ resource.Resource                = &RecordResource{}
-	_ resource.ResourceWithImportState = &RecordResource{}
-	_ resource.ResourceWithConfigure   = &RecordResource{}
+	_ resource.Resource                   = &RecordResource{}
+	_ resource.ResourceWithImportState    = &RecordResource{}
+	_ resource.ResourceWithConfigure      = &RecordResource{}
+	_ resource.ResourceWithValidateConfig = &RecordResource{}
 )
 
 func NewRecordResource() resource.Resource {
@@ -36,7 +43,9 @@ type RecordResource struct {
 
 type RecordResourceModel struct {
 	Comments types.List   `tfsdk:"comments"`
+	Comment  types.List   `tfsdk:"comment"`
 	Records  types.List   `tfsdk:"records"`
+	Record   types.Set    `tfsdk:"record"`
 	Zone     types.String `tfsdk:"zone"`
 	Type     types.String `tfsdk:"type"`
 	Name     types.String `tfsdk:"name"`
@@ -71,7 +80,10 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			},
 			"type": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Type of the record e.g. A, AAAA or CNAME",
+				MarkdownDescription: "Type of the record in upper case e.g. A, AAAA or CNAME",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Z][A-Z0-9]*$`), "Type must be upper case, e.g. MX instead of mx"),
+				},
 			},
 			"ttl": schema.Int64Attribute{
 				Optional:            true,
@@ -83,15 +95,72 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				ElementType:         types.StringType,
 				MarkdownDescription: "List of comments to append to the record",
 				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("comment")),
+				},
+			},
+			"comment": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "The comments of the record with the account that wrote them, as an alternative to `comments`.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"content": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Text of the comment.",
+						},
+						"account": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Account that wrote the comment, e.g. the owner of a change ticket.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"modified_at": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Unix timestamp of the last change of the comment. Kept as long as `content` and `account` do not change.",
+							PlanModifiers: []planmodifier.Int64{
+								commentModifiedAtPlanModifier{},
+							},
+						},
+					},
+				},
 			},
 			"records": schema.ListAttribute{
 				ElementType:         types.StringType,
-				Required:            true,
-				MarkdownDescription: "RValue to which the record points. For A type records this are IP Addresses. For CNAMEs this are other FQDNs and so on.",
+				Optional:            true,
+				MarkdownDescription: "RValue to which the record points. For A type records this are IP Addresses. For CNAMEs this are other FQDNs and so on. The content of common types like A, MX, SRV, TXT or CAA is validated at plan time, e.g. names must end with a dot and TXT strings must be quoted. Contents PowerDNS normalizes, e.g. by compressing IPv6 addresses or lower-casing hostnames, keep the configured form. Exactly one of `records` or `record` must be set. Records disabled outside of Terraform are not listed, so they show up as a change.",
 				Validators: []validator.List{
 					listvalidator.SizeAtLeast(1),
 					listvalidator.UniqueValues(),
 					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+					listvalidator.ExactlyOneOf(path.MatchRoot("record")),
+				},
+			},
+			"record": schema.SetNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "The records of the rrset with their state, as an alternative to `records`. Allows to disable single records, e.g. to take one backend out of a round-robin set without deleting it.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"content": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "RValue to which the record points, like an entry of `records`.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"disabled": schema.BoolAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+							MarkdownDescription: "Whether the record is disabled and therefore not served. Defaults to `false`.",
+						},
+					},
 				},
 			},
 		},
@@ -114,6 +183,61 @@ func (r *RecordResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.providerData = providerData
 }
 
+// ValidateConfig checks the content of every record against the format of the
+// type, so malformed records fail at plan time instead of with an error of
+// PowerDNS during apply.
+func (r *RecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RecordResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Type.IsUnknown() || data.Type.IsNull() {
+		return
+	}
+
+	rrType := data.Type.ValueString()
+	validate := func(attributePath path.Path, content types.String) {
+		if content.IsUnknown() || content.IsNull() {
+			return
+		}
+		if err := validateRecordContent(rrType, content.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				attributePath,
+				"Invalid Record Content",
+				fmt.Sprintf("The %s record %q is invalid: %s.", rrType, content.ValueString(), err),
+			)
+		}
+	}
+
+	for i, element := range data.Records.Elements() {
+		if content, ok := element.(types.String); ok {
+			validate(path.Root("records").AtListIndex(i), content)
+		}
+	}
+	for _, element := range data.Record.Elements() {
+		if record, ok := element.(types.Object); ok {
+			if content, ok := record.Attributes()["content"].(types.String); ok {
+				validate(path.Root("record").AtSetValue(element).AtName("content"), content)
+			}
+		}
+	}
+
+	if rrType == "CNAME" && len(data.Records.Elements()) > 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("records"),
+			"Invalid Record Content",
+			"A CNAME rrset must contain exactly one record.",
+		)
+	}
+	if rrType == "CNAME" && len(data.Record.Elements()) > 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("record"),
+			"Invalid Record Content",
+			"A CNAME rrset must contain exactly one record.",
+		)
+	}
+}
+
 func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data RecordResourceModel
 
@@ -123,43 +247,25 @@ func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	records := make([]string, 0, len(data.Records.Elements()))
-	diags := data.Records.ElementsAs(ctx, &records, false)
+	records, diags := recordsFromModel(ctx, data)
 	if diags.HasError() {
 		resp.Diagnostics = append(resp.Diagnostics, diags...)
 		return
 	}
 
-	var comments []string
-	if !data.Comments.IsNull() {
-		comments = make([]string, 0, len(data.Comments.Elements()))
-		diags := data.Comments.ElementsAs(ctx, &comments, false)
-		if diags.HasError() {
-			resp.Diagnostics = append(resp.Diagnostics, diags...)
-			return
-		}
-	} else {
-		comments = make([]string, 0)
+	comments, diags := commentsFromModel(ctx, data)
+	if diags.HasError() {
+		resp.Diagnostics = append(resp.Diagnostics, diags...)
+		return
 	}
 
-	err := r.providerData.pdnsClient.UpdateZoneRecords(ctx, data.Zone.ValueString(), []pdns_client.Rrset{{
-		Type:       data.Type.ValueString(),
-		TTL:        data.TTL.ValueInt64(),
-		Changetype: "REPLACE",
-		Name:       fqdn(data.Name.ValueString(), data.Zone.ValueString()),
-		Records: lo.Map(records, func(item string, index int) pdns_client.Record {
-			return pdns_client.Record{
-				Content:  item,
-				Disabled: false,
-			}
-		}),
-		Comments: lo.Map(comments, func(item string, index int) pdns_client.Comment {
-			return pdns_client.Comment{
-				Content: item,
-			}
-		}),
-	}})
-	if handleClientError(&resp.Diagnostics, err) {
+	err := replaceRrset(ctx, r.providerData, data.Zone.ValueString(), data.Name.ValueString(), data.Type.ValueString(), data.TTL.ValueInt64(), records, comments)
+	if handleRecordClientError(&resp.Diagnostics, err, data.errorAttributes()) {
+		return
+	}
+
+	resp.Diagnostics.Append(r.readCommentTimestamps(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -176,24 +282,24 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	expandedName := fqdn(data.Name.ValueString(), data.Zone.ValueString())
-	zone, err := r.providerData.pdnsClient.GetZone(ctx, data.Zone.ValueString(), true, expandedName)
-	if handleClientError(&resp.Diagnostics, err) {
+	rrset, found, err := readRrset(ctx, r.providerData, data.Zone.ValueString(), data.Name.ValueString(), data.Type.ValueString())
+	if handleRecordClientError(&resp.Diagnostics, err, data.errorAttributes()) {
 		return
 	}
-
-	rrset, isFound := lo.Find(zone.Rrsets, func(item pdns_client.Rrset) bool {
-		return item.Type == data.Type.ValueString()
-	})
-
-	if !isFound {
-		resp.Diagnostics.AddError("Record not found", fmt.Sprintf("Failed to find a record with name '%s' and type '%s'", expandedName, data.Type.ValueString()))
+	if !found {
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
 	data.TTL = types.Int64Value(rrset.TTL)
 	data.Type = types.StringValue(rrset.Type)
 
+	// Like for records, an imported rrset uses `comment` only if that is
+	// needed to keep the accounts.
+	useComment := !data.Comment.IsNull() || (data.Comments.IsNull() && lo.ContainsBy(rrset.Comments, func(item pdns_client.Comment) bool {
+		return item.Account != ""
+	}))
+
 	listValue, diags := types.ListValueFrom(ctx, types.StringType, lo.Map(rrset.Comments, func(item pdns_client.Comment, index int) attr.Value {
 		return types.StringValue(item.Content)
 	}))
@@ -202,21 +308,69 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	if len(listValue.Elements()) == 0 {
-		data.Comments = types.ListNull(types.StringType)
-	} else {
+	data.Comments = types.ListNull(types.StringType)
+	data.Comment = types.ListNull(types.ObjectType{AttrTypes: RrsetCommentModel{}.AttributeTypes()})
+	switch {
+	case len(rrset.Comments) == 0:
+	case useComment:
+		data.Comment, diags = commentModelsToList(ctx, rrset.Comments)
+		if diags.HasError() {
+			resp.Diagnostics = append(resp.Diagnostics, diags...)
+			return
+		}
+	default:
 		data.Comments = listValue
 	}
 
-	listValue, diags = types.ListValueFrom(ctx, types.StringType, lo.Map(rrset.Records, func(item pdns_client.Record, index int) attr.Value {
-		return types.StringValue(item.Content)
-	}))
+	// PowerDNS normalizes the content of records, e.g. it compresses IPv6
+	// addresses. Contents equivalent to the prior state keep its form.
+	prior, diags := recordsFromModel(ctx, data)
 	if diags.HasError() {
 		resp.Diagnostics = append(resp.Diagnostics, diags...)
 		return
 	}
+	priorContents := lo.Map(prior, func(item pdns_client.Record, index int) string {
+		return item.Content
+	})
+	content := func(record pdns_client.Record) types.String {
+		return types.StringValue(equivalentRecordContent(rrset.Type, priorContents, record.Content))
+	}
+
+	// An imported rrset uses `record` only if that is needed to keep disabled
+	// records.
+	useRecord := !data.Record.IsNull() || (data.Records.IsNull() && lo.ContainsBy(rrset.Records, func(item pdns_client.Record) bool {
+		return item.Disabled
+	}))
 
-	data.Records = listValue
+	if useRecord {
+		setValue, diags := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: RrsetRecordModel{}.AttributeTypes()}, lo.Map(rrset.Records, func(item pdns_client.Record, index int) RrsetRecordModel {
+			return RrsetRecordModel{
+				Content:  content(item),
+				Disabled: types.BoolValue(item.Disabled),
+			}
+		}))
+		if diags.HasError() {
+			resp.Diagnostics = append(resp.Diagnostics, diags...)
+			return
+		}
+
+		data.Record = setValue
+		data.Records = types.ListNull(types.StringType)
+	} else {
+		enabled := lo.Filter(rrset.Records, func(item pdns_client.Record, index int) bool {
+			return !item.Disabled
+		})
+		listValue, diags = types.ListValueFrom(ctx, types.StringType, lo.Map(enabled, func(item pdns_client.Record, index int) attr.Value {
+			return content(item)
+		}))
+		if diags.HasError() {
+			resp.Diagnostics = append(resp.Diagnostics, diags...)
+			return
+		}
+
+		data.Records = listValue
+		data.Record = types.SetNull(types.ObjectType{AttrTypes: RrsetRecordModel{}.AttributeTypes()})
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -232,43 +386,25 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	records := make([]string, 0, len(plan.Records.Elements()))
-	diags := plan.Records.ElementsAs(ctx, &records, false)
+	records, diags := recordsFromModel(ctx, plan)
 	if diags.HasError() {
 		resp.Diagnostics = append(resp.Diagnostics, diags...)
 		return
 	}
 
-	var comments []string
-	if !plan.Comments.IsNull() {
-		comments = make([]string, 0, len(plan.Comments.Elements()))
-		diags := plan.Comments.ElementsAs(ctx, &comments, false)
-		if diags.HasError() {
-			resp.Diagnostics = append(resp.Diagnostics, diags...)
-			return
-		}
-	} else {
-		comments = make([]string, 0)
+	comments, diags := commentsFromModel(ctx, plan)
+	if diags.HasError() {
+		resp.Diagnostics = append(resp.Diagnostics, diags...)
+		return
 	}
 
-	err := r.providerData.pdnsClient.UpdateZoneRecords(ctx, plan.Zone.ValueString(), []pdns_client.Rrset{{
-		Type:       plan.Type.ValueString(),
-		TTL:        plan.TTL.ValueInt64(),
-		Changetype: "REPLACE",
-		Name:       fqdn(plan.Name.ValueString(), plan.Zone.ValueString()),
-		Records: lo.Map(records, func(item string, index int) pdns_client.Record {
-			return pdns_client.Record{
-				Content:  item,
-				Disabled: false,
-			}
-		}),
-		Comments: lo.Map(comments, func(item string, index int) pdns_client.Comment {
-			return pdns_client.Comment{
-				Content: item,
-			}
-		}),
-	}})
-	if handleClientError(&resp.Diagnostics, err) {
+	err := replaceRrset(ctx, r.providerData, plan.Zone.ValueString(), plan.Name.ValueString(), plan.Type.ValueString(), plan.TTL.ValueInt64(), records, comments)
+	if handleRecordClientError(&resp.Diagnostics, err, plan.errorAttributes()) {
+		return
+	}
+
+	resp.Diagnostics.Append(r.readCommentTimestamps(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -284,15 +420,262 @@ func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.providerData.pdnsClient.UpdateZoneRecords(ctx, data.Zone.ValueString(), []pdns_client.Rrset{{
-		Type:       data.Type.ValueString(),
+	err := deleteRrset(ctx, r.providerData, data.Zone.ValueString(), data.Name.ValueString(), data.Type.ValueString())
+	handleRecordClientError(&resp.Diagnostics, err, data.errorAttributes())
+}
+
+// ImportState accepts either `<zone>:<name>:<type>` (e.g. `example.com.:www:A`)
+// or `<fqdn>/<type>` (e.g. `www.example.com./A`). For the latter the zone is
+// the longest zone on the server the name belongs to. Read then fills in the
+// remaining attributes.
+func (r *RecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, rrType := "", ""
+
+	if parts := strings.Split(req.ID, ":"); len(parts) == 3 {
+		id, rrType = parts[0]+":"+parts[1], parts[2]
+	} else if fqdnName, fqdnType, found := strings.Cut(req.ID, "/"); found && !strings.Contains(fqdnType, "/") {
+		id, rrType = fqdnName, fqdnType
+	}
+
+	zone, name, diags := resolveRecordName(ctx, r.providerData, id)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if zone == "" || name == "" || rrType == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID like 'example.com.:www:A' or 'www.example.com./A', got '%s'", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), zone)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), strings.ToUpper(rrType))...)
+}
+
+// resolveRecordName returns the zone and name of a record given either as
+// `<zone>:<name>` or as a fully qualified name, for which the zone is the
+// longest zone on the server the name belongs to. Both are empty if id has
+// neither form.
+func resolveRecordName(ctx context.Context, providerData *PDNSProviderData, id string) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if zone, name, found := strings.Cut(id, ":"); found {
+		if zone == "" || name == "" || strings.Contains(name, ":") {
+			return "", "", diags
+		}
+		if !strings.HasSuffix(zone, ".") {
+			zone += "."
+		}
+		return zone, name, diags
+	}
+	if id == "" {
+		return "", "", diags
+	}
+
+	if !strings.HasSuffix(id, ".") {
+		id += "."
+	}
+
+	zones, err := providerData.pdnsClient.ListZones(ctx, "", false)
+	if handleClientError(&diags, err) {
+		return "", "", diags
+	}
+
+	zone := longestMatchingZone(id, lo.Map(zones, func(item pdns_client.PDNSZone, index int) string {
+		return item.Name
+	}))
+	if zone == "" {
+		diags.AddError("Import Error", fmt.Sprintf("No zone on the server contains the name '%s'", id))
+		return "", "", diags
+	}
+
+	return zone, relativeName(id, zone), diags
+}
+
+// replaceRrset creates or replaces the rrset of a record resource through
+// the batcher, so that the changes of many resources share a PATCH. Nil
+// comments leave the comments of the rrset as they are, an empty slice
+// removes them.
+func replaceRrset(ctx context.Context, providerData *PDNSProviderData, zone string, name string, rrType string, ttl int64, records []pdns_client.Record, comments []pdns_client.Comment) error {
+	return providerData.recordBatcher.UpdateZoneRecords(ctx, zone, []pdns_client.Rrset{{
+		Type:       rrType,
+		TTL:        ttl,
+		Changetype: "REPLACE",
+		Name:       fqdn(name, zone),
+		Records:    records,
+		Comments:   comments,
+	}})
+}
+
+// deleteRrset deletes the rrset of a record resource through the batcher.
+func deleteRrset(ctx context.Context, providerData *PDNSProviderData, zone string, name string, rrType string) error {
+	return providerData.recordBatcher.UpdateZoneRecords(ctx, zone, []pdns_client.Rrset{{
+		Type:       rrType,
 		Changetype: "DELETE",
-		Name:       fqdn(data.Name.ValueString(), data.Zone.ValueString()),
+		Name:       fqdn(name, zone),
 	}})
-	handleClientError(&resp.Diagnostics, err)
 }
 
-// TODO: Import record by name
-func (r *RecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+// readRrset returns the rrset of a record resource. It reports false if the
+// rrset or its zone were deleted outside of Terraform.
+func readRrset(ctx context.Context, providerData *PDNSProviderData, zone string, name string, rrType string) (pdns_client.Rrset, bool, error) {
+	expandedName := fqdn(name, zone)
+	rrset, err := providerData.pdnsClient.GetRrset(ctx, zone, expandedName, rrType)
+
+	var zoneNotFoundError *pdns_client.PDNSZoneNotFoundError
+	var rrsetNotFoundError *pdns_client.PDNSRrsetNotFoundError
+	if errors.As(err, &zoneNotFoundError) || errors.As(err, &rrsetNotFoundError) {
+		tflog.Warn(ctx, "Record was deleted outside of Terraform, removing it from state", map[string]any{
+			"zone": zone,
+			"name": expandedName,
+			"type": rrType,
+		})
+		return pdns_client.Rrset{}, false, nil
+	}
+	if err != nil {
+		return pdns_client.Rrset{}, false, err
+	}
+
+	return rrset, true, nil
+}
+
+// recordsFromModel returns the records configured with either `records` or
+// `record`.
+func recordsFromModel(ctx context.Context, data RecordResourceModel) ([]pdns_client.Record, diag.Diagnostics) {
+	if !data.Record.IsNull() {
+		models := make([]RrsetRecordModel, 0, len(data.Record.Elements()))
+		diags := data.Record.ElementsAs(ctx, &models, false)
+
+		return lo.Map(models, func(item RrsetRecordModel, index int) pdns_client.Record {
+			return pdns_client.Record{
+				Content:  item.Content.ValueString(),
+				Disabled: item.Disabled.ValueBool(),
+			}
+		}), diags
+	}
+
+	records := make([]string, 0, len(data.Records.Elements()))
+	diags := data.Records.ElementsAs(ctx, &records, false)
+
+	return lo.Map(records, func(item string, index int) pdns_client.Record {
+		return pdns_client.Record{
+			Content: item,
+		}
+	}), diags
+}
+
+// errorAttributes returns the attributes rrset rejections are attached to,
+// with content errors on whichever of `records` and `record` is configured.
+func (data RecordResourceModel) errorAttributes() []apiErrorAttribute {
+	return recordErrorAttributes(lo.Ternary(data.Record.IsNull(), "records", "record"))
+}
+
+// commentsFromModel returns the comments configured with either `comments` or
+// `comment`. Comments whose modification time is known from the prior state
+// keep it, PowerDNS sets it to now for the others.
+func commentsFromModel(ctx context.Context, data RecordResourceModel) ([]pdns_client.Comment, diag.Diagnostics) {
+	if !data.Comment.IsNull() {
+		models := make([]RrsetCommentModel, 0, len(data.Comment.Elements()))
+		diags := data.Comment.ElementsAs(ctx, &models, false)
+
+		return lo.Map(models, func(item RrsetCommentModel, index int) pdns_client.Comment {
+			return pdns_client.Comment{
+				Content:    item.Content.ValueString(),
+				Account:    item.Account.ValueString(),
+				ModifiedAt: item.ModifiedAt.ValueInt64(),
+			}
+		}), diags
+	}
+
+	comments := make([]string, 0, len(data.Comments.Elements()))
+	diags := data.Comments.ElementsAs(ctx, &comments, false)
+
+	return lo.Map(comments, func(item string, index int) pdns_client.Comment {
+		return pdns_client.Comment{
+			Content: item,
+		}
+	}), diags
+}
+
+// commentModelsToList converts comments for the `comment` attribute, which
+// has no account if PowerDNS reports an empty one.
+func commentModelsToList(ctx context.Context, comments []pdns_client.Comment) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(
+		ctx,
+		types.ObjectType{AttrTypes: RrsetCommentModel{}.AttributeTypes()},
+		lo.Map(comments, func(item pdns_client.Comment, index int) RrsetCommentModel {
+			return RrsetCommentModel{
+				Content:    types.StringValue(item.Content),
+				Account:    lo.Ternary(item.Account == "", types.StringNull(), types.StringValue(item.Account)),
+				ModifiedAt: types.Int64Value(item.ModifiedAt),
+			}
+		}),
+	)
+}
+
+// readCommentTimestamps fills in the modification times PowerDNS set for new
+// or changed comments after a write.
+func (r *RecordResource) readCommentTimestamps(ctx context.Context, data *RecordResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if data.Comment.IsNull() {
+		return diags
+	}
+
+	models := make([]RrsetCommentModel, 0, len(data.Comment.Elements()))
+	diags.Append(data.Comment.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() || !lo.ContainsBy(models, func(item RrsetCommentModel) bool { return item.ModifiedAt.IsUnknown() }) {
+		return diags
+	}
+
+	rrset, err := r.providerData.pdnsClient.GetRrset(ctx, data.Zone.ValueString(), fqdn(data.Name.ValueString(), data.Zone.ValueString()), data.Type.ValueString())
+	if handleRecordClientError(&diags, err, data.errorAttributes()) {
+		return diags
+	}
+
+	list, d := commentModelsToList(ctx, rrset.Comments)
+	diags.Append(d...)
+	data.Comment = list
+	return diags
+}
+
+var _ planmodifier.Int64 = commentModifiedAtPlanModifier{}
+
+// commentModifiedAtPlanModifier keeps the modification time of a comment
+// whose content and account are unchanged, wherever it moved in the list, so
+// it is sent back to PowerDNS instead of being reset to now.
+type commentModifiedAtPlanModifier struct{}
+
+func (m commentModifiedAtPlanModifier) Description(ctx context.Context) string {
+	return "Keeps the modification time of unchanged comments."
+}
+
+func (m commentModifiedAtPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m commentModifiedAtPlanModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	if req.State.Raw.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var planned RrsetCommentModel
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, req.Path.ParentPath(), &planned)...)
+
+	var prior []RrsetCommentModel
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("comment"), &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	match, found := lo.Find(prior, func(item RrsetCommentModel) bool {
+		return item.Content.Equal(planned.Content) && item.Account.Equal(planned.Account)
+	})
+	if found {
+		resp.PlanValue = match.ModifiedAt
+	}
 }
@@ -0,0 +1,187 @@
+package provider_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/acctest"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+func testRecordConfig(records ...any) map[string]any {
+	return map[string]any{
+		"zone":    "example.com.",
+		"name":    "www",
+		"type":    "A",
+		"ttl":     300,
+		"records": records,
+	}
+}
+
+func rrsetContents(rrset pdns_client.Rrset) []string {
+	contents := make([]string, 0, len(rrset.Records))
+	for _, record := range rrset.Records {
+		contents = append(contents, record.Content)
+	}
+	slices.Sort(contents)
+	return contents
+}
+
+func TestAccRecordResource(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	if _, err := d.Create("pdns_zone", testZoneConfig(10800)); err != nil {
+		t.Fatalf("create zone: %s", err)
+	}
+
+	state, err := d.Create("pdns_record", testRecordConfig("10.0.0.1", "10.0.0.2"))
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	rrset, ok := d.Server.Rrset("example.com.", "www.example.com.", "A")
+	if !ok {
+		t.Fatal("rrset was not created")
+	}
+	if got, want := rrsetContents(rrset), []string{"10.0.0.1", "10.0.0.2"}; !slices.Equal(got, want) {
+		t.Errorf("records = %v, want %v", got, want)
+	}
+	if rrset.TTL != 300 {
+		t.Errorf("ttl = %d, want 300", rrset.TTL)
+	}
+
+	read, err := d.Read("pdns_record", state)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if !read.Equal(state) {
+		t.Errorf("read drifted from state:\n got: %s\nwant: %s", read, state)
+	}
+
+	for _, id := range []string{"example.com.:www:A", "www.example.com./A"} {
+		imported, err := d.Import("pdns_record", id)
+		if err != nil {
+			t.Fatalf("import %q: %s", id, err)
+		}
+		planned, err := d.Plan("pdns_record", imported, testRecordConfig("10.0.0.1", "10.0.0.2"))
+		if err != nil {
+			t.Fatalf("plan after import %q: %s", id, err)
+		}
+		if !planned.Equal(imported) {
+			t.Errorf("plan after import %q is not empty:\n got: %s\nwant: %s", id, planned, imported)
+		}
+	}
+
+	state, err = d.Update("pdns_record", state, testRecordConfig("10.0.0.3"))
+	if err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	rrset, _ = d.Server.Rrset("example.com.", "www.example.com.", "A")
+	if got, want := rrsetContents(rrset), []string{"10.0.0.3"}; !slices.Equal(got, want) {
+		t.Errorf("records after update = %v, want %v", got, want)
+	}
+
+	if err := d.Destroy("pdns_record", state); err != nil {
+		t.Fatalf("destroy: %s", err)
+	}
+	if _, ok := d.Server.Rrset("example.com.", "www.example.com.", "A"); ok {
+		t.Error("rrset still exists after destroy")
+	}
+}
+
+func TestAccRecordResource_removedOutOfBand(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	if _, err := d.Create("pdns_zone", testZoneConfig(10800)); err != nil {
+		t.Fatalf("create zone: %s", err)
+	}
+	state, err := d.Create("pdns_record", testRecordConfig("10.0.0.1"))
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	d.Server.RemoveZone("example.com.")
+
+	read, err := d.Read("pdns_record", state)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if !read.IsNull() {
+		t.Errorf("record was not removed from state: %s", read)
+	}
+}
+
+func TestAccRecordResource_invalidImportID(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	for _, id := range []string{"garbage", "www.nope.org./A"} {
+		if _, err := d.Import("pdns_record", id); err == nil {
+			t.Errorf("import %q succeeded, want error", id)
+		}
+	}
+}
+
+func TestAccRecordResource_removeComments(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	if _, err := d.Create("pdns_zone", testZoneConfig(10800)); err != nil {
+		t.Fatalf("create zone: %s", err)
+	}
+
+	config := testRecordConfig("10.0.0.1")
+	config["comments"] = []any{"web frontend"}
+	state, err := d.Create("pdns_record", config)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	if rrset, _ := d.Server.Rrset("example.com.", "www.example.com.", "A"); len(rrset.Comments) != 1 {
+		t.Fatalf("comments = %+v, want one", rrset.Comments)
+	}
+
+	// Removing all comments must send an empty list, as PowerDNS keeps the
+	// comments of a replaced rrset otherwise and the plan never converges.
+	config = testRecordConfig("10.0.0.1")
+	state, err = d.Update("pdns_record", state, config)
+	if err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	if rrset, _ := d.Server.Rrset("example.com.", "www.example.com.", "A"); len(rrset.Comments) != 0 {
+		t.Errorf("comments after removing them = %+v, want none", rrset.Comments)
+	}
+
+	read, err := d.Read("pdns_record", state)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	planned, err := d.Plan("pdns_record", read, config)
+	if err != nil {
+		t.Fatalf("plan: %s", err)
+	}
+	if !planned.Equal(read) {
+		t.Errorf("plan after removing comments is not empty:\n got: %s\nwant: %s", planned, read)
+	}
+}
+
+func TestAccRecordResource_lowerCaseType(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	if _, err := d.Create("pdns_zone", testZoneConfig(10800)); err != nil {
+		t.Fatalf("create zone: %s", err)
+	}
+
+	// PowerDNS stores and looks up types case-sensitively, so a lower-case
+	// type would never be found again on refresh.
+	config := testRecordConfig("10 mail.example.com.")
+	config["type"] = "mx"
+	_, err := d.Create("pdns_record", config)
+	if err == nil {
+		t.Fatal("creating a record with a lower-case type succeeded, want error")
+	}
+	if !strings.Contains(err.Error(), "type") {
+		t.Errorf("error = %q, want it to point at type", err)
+	}
+	if _, ok := d.Server.Rrset("example.com.", "www.example.com.", "mx"); ok {
+		t.Error("rrset with a lower-case type was created")
+	}
+}
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+var (
+	_ resource.Resource                = &TSIGKeyResource{}
+	_ resource.ResourceWithImportState = &TSIGKeyResource{}
+	_ resource.ResourceWithConfigure   = &TSIGKeyResource{}
+)
+
+func NewTSIGKeyResource() resource.Resource {
+	return &TSIGKeyResource{}
+}
+
+type TSIGKeyResource struct {
+	providerData *PDNSProviderData
+}
+
+type TSIGKeyResourceModel struct {
+	Name      types.String `tfsdk:"name"`
+	KeyID     types.String `tfsdk:"key_id"`
+	Algorithm types.String `tfsdk:"algorithm"`
+	Key       types.String `tfsdk:"key"`
+}
+
+func (r *TSIGKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tsig_key"
+}
+
+func (r *TSIGKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a TSIG key of PowerDNS, used to authenticate zone transfers. Reference it from the `master_tsig_key_ids` or `slave_tsig_key_ids` of a `pdns_zone`.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the key, e.g. `axfr-example`. Must not end with a dot.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.RegexMatches(regexp.MustCompile(`[^.]$`), "Name must not end with a dot"),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The id PowerDNS derived from the name, e.g. `axfr-example.`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"algorithm": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("hmac-sha256"),
+				MarkdownDescription: "The HMAC algorithm of the key. One of `hmac-md5`, `hmac-sha1`, `hmac-sha224`, `hmac-sha256`, `hmac-sha384` or `hmac-sha512`. Defaults to `hmac-sha256`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("hmac-md5", "hmac-sha1", "hmac-sha224", "hmac-sha256", "hmac-sha384", "hmac-sha512"),
+				},
+			},
+			"key": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The base64 encoded secret of the key. PowerDNS generates one if unset.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *TSIGKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*PDNSProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError("Parse Error", "Failed to parse provider data")
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *TSIGKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TSIGKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tsigKey, err := r.providerData.pdnsClient.CreateTSIGKey(ctx, pdns_client.TSIGKey{
+		Name:      data.Name.ValueString(),
+		Algorithm: data.Algorithm.ValueString(),
+		Key:       data.Key.ValueString(),
+	})
+	if handleClientError(&resp.Diagnostics, err) {
+		return
+	}
+
+	data.fromTSIGKey(tsigKey)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TSIGKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TSIGKeyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tsigKey, err := r.providerData.pdnsClient.GetTSIGKey(ctx, data.KeyID.ValueString())
+
+	var notFoundError *pdns_client.PDNSTSIGKeyNotFoundError
+	if errors.As(err, &notFoundError) {
+		tflog.Warn(ctx, "TSIG key was deleted outside of Terraform, removing it from state", map[string]any{"key_id": data.KeyID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if handleClientError(&resp.Diagnostics, err) {
+		return
+	}
+
+	data.fromTSIGKey(tsigKey)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TSIGKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan TSIGKeyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The name requires a replacement, so the id stays the same.
+	tsigKey, err := r.providerData.pdnsClient.UpdateTSIGKey(ctx, plan.KeyID.ValueString(), pdns_client.TSIGKey{
+		Algorithm: plan.Algorithm.ValueString(),
+		Key:       plan.Key.ValueString(),
+	})
+	if handleClientError(&resp.Diagnostics, err) {
+		return
+	}
+
+	plan.fromTSIGKey(tsigKey)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TSIGKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TSIGKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.providerData.pdnsClient.DeleteTSIGKey(ctx, data.KeyID.ValueString())
+	handleClientError(&resp.Diagnostics, err)
+}
+
+// ImportState imports a key by its id, e.g. `axfr-example.`. The trailing dot
+// may be omitted.
+func (r *TSIGKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	keyID := req.ID
+	if !strings.HasSuffix(keyID, ".") {
+		keyID += "."
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key_id"), keyID)...)
+}
+
+func (m *TSIGKeyResourceModel) fromTSIGKey(tsigKey pdns_client.TSIGKey) {
+	m.KeyID = types.StringValue(tsigKey.ID)
+	m.Name = types.StringValue(tsigKey.Name)
+	m.Algorithm = types.StringValue(tsigKey.Algorithm)
+	m.Key = types.StringValue(tsigKey.Key)
+}
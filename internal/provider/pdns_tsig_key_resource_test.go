@@ -0,0 +1,159 @@
+package provider_test
+
+import (
+	"slices"
+	"testing"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/acctest"
+)
+
+func TestAccTSIGKeyResource(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	// Without a key PowerDNS generates the secret, which has to end up in state.
+	config := map[string]any{"name": "axfr-example"}
+	state, err := d.Create("pdns_tsig_key", config)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	tsigKey, ok := d.Server.TSIGKey("axfr-example.")
+	if !ok {
+		t.Fatal("TSIG key was not created")
+	}
+	if got := acctest.StringAttribute(state, "key_id"); got != "axfr-example." {
+		t.Errorf("key_id = %q, want %q", got, "axfr-example.")
+	}
+	if tsigKey.Algorithm != "hmac-sha256" {
+		t.Errorf("algorithm on the server = %q, want the provider default hmac-sha256", tsigKey.Algorithm)
+	}
+	if tsigKey.Key == "" {
+		t.Fatal("the server did not generate a secret")
+	}
+	if got := acctest.StringAttribute(state, "key"); got != tsigKey.Key {
+		t.Errorf("key = %q, want the generated %q", got, tsigKey.Key)
+	}
+
+	read, err := d.Read("pdns_tsig_key", state)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if !read.Equal(state) {
+		t.Errorf("read drifted from state:\n got: %s\nwant: %s", read, state)
+	}
+
+	planned, err := d.Plan("pdns_tsig_key", state, config)
+	if err != nil {
+		t.Fatalf("plan: %s", err)
+	}
+	if !planned.Equal(state) {
+		t.Errorf("plan with the generated secret is not empty:\n got: %s\nwant: %s", planned, state)
+	}
+
+	// Changing the algorithm and secret is done in place.
+	config = map[string]any{"name": "axfr-example", "algorithm": "hmac-sha512", "key": "c2VjcmV0"}
+	updated, err := d.Update("pdns_tsig_key", state, config)
+	if err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	tsigKey, _ = d.Server.TSIGKey("axfr-example.")
+	if tsigKey.Algorithm != "hmac-sha512" || tsigKey.Key != "c2VjcmV0" {
+		t.Errorf("key after update = %+v, want hmac-sha512 with secret c2VjcmV0", tsigKey)
+	}
+	if got := acctest.StringAttribute(updated, "key_id"); got != "axfr-example." {
+		t.Errorf("key_id after update = %q, want %q", got, "axfr-example.")
+	}
+	if got := acctest.StringAttribute(updated, "key"); got != "c2VjcmV0" {
+		t.Errorf("key after update = %q, want %q", got, "c2VjcmV0")
+	}
+
+	// The trailing dot of the id may be omitted on import.
+	imported, err := d.Import("pdns_tsig_key", "axfr-example")
+	if err != nil {
+		t.Fatalf("import: %s", err)
+	}
+	if !imported.Equal(updated) {
+		t.Errorf("import differs from state:\n got: %s\nwant: %s", imported, updated)
+	}
+	planned, err = d.Plan("pdns_tsig_key", imported, config)
+	if err != nil {
+		t.Fatalf("plan after import: %s", err)
+	}
+	if !planned.Equal(imported) {
+		t.Errorf("plan after import is not empty:\n got: %s\nwant: %s", planned, imported)
+	}
+
+	if err := d.Destroy("pdns_tsig_key", updated); err != nil {
+		t.Fatalf("destroy: %s", err)
+	}
+	if _, ok := d.Server.TSIGKey("axfr-example."); ok {
+		t.Error("TSIG key still exists after destroy")
+	}
+}
+
+func TestAccTSIGKeyResource_zoneKeyIDs(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	for _, name := range []string{"axfr-out", "axfr-in"} {
+		if _, err := d.Create("pdns_tsig_key", map[string]any{"name": name}); err != nil {
+			t.Fatalf("create %s: %s", name, err)
+		}
+	}
+
+	config := testZoneConfig(10800)
+	config["master_tsig_key_ids"] = []any{"axfr-out."}
+	state, err := d.Create("pdns_zone", config)
+	if err != nil {
+		t.Fatalf("create zone: %s", err)
+	}
+
+	zone, _ := d.Server.Zone("example.com.")
+	if !slices.Equal(zone.MasterTsigKeyIDS, []string{"axfr-out."}) {
+		t.Errorf("master_tsig_key_ids on the server = %q, want [axfr-out.]", zone.MasterTsigKeyIDS)
+	}
+	if len(zone.SlaveTsigKeyIDS) != 0 {
+		t.Errorf("slave_tsig_key_ids on the server = %q, want none", zone.SlaveTsigKeyIDS)
+	}
+
+	read, err := d.Read("pdns_zone", state)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if !read.Equal(state) {
+		t.Errorf("read drifted from state:\n got: %s\nwant: %s", read, state)
+	}
+
+	config["master_tsig_key_ids"] = []any{"axfr-out.", "axfr-in."}
+	config["slave_tsig_key_ids"] = []any{"axfr-in."}
+	state, err = d.Update("pdns_zone", state, config)
+	if err != nil {
+		t.Fatalf("update: %s", err)
+	}
+
+	zone, _ = d.Server.Zone("example.com.")
+	if !slices.Equal(zone.MasterTsigKeyIDS, []string{"axfr-out.", "axfr-in."}) {
+		t.Errorf("master_tsig_key_ids on the server = %q, want [axfr-out. axfr-in.]", zone.MasterTsigKeyIDS)
+	}
+	if !slices.Equal(zone.SlaveTsigKeyIDS, []string{"axfr-in."}) {
+		t.Errorf("slave_tsig_key_ids on the server = %q, want [axfr-in.]", zone.SlaveTsigKeyIDS)
+	}
+	if got := acctest.StringsAttribute(state, "slave_tsig_key_ids"); !slices.Equal(got, []string{"axfr-in."}) {
+		t.Errorf("slave_tsig_key_ids = %q, want [axfr-in.]", got)
+	}
+
+	// Removing the keys from the configuration clears them on the server.
+	delete(config, "master_tsig_key_ids")
+	delete(config, "slave_tsig_key_ids")
+	state, err = d.Update("pdns_zone", state, config)
+	if err != nil {
+		t.Fatalf("update: %s", err)
+	}
+
+	zone, _ = d.Server.Zone("example.com.")
+	if len(zone.MasterTsigKeyIDS) != 0 || len(zone.SlaveTsigKeyIDS) != 0 {
+		t.Errorf("TSIG key ids on the server = %q / %q, want none", zone.MasterTsigKeyIDS, zone.SlaveTsigKeyIDS)
+	}
+	if !acctest.Attribute(state, "master_tsig_key_ids").IsNull() || !acctest.Attribute(state, "slave_tsig_key_ids").IsNull() {
+		t.Error("TSIG key ids are still set in state after removing them")
+	}
+}
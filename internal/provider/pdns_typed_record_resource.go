@@ -0,0 +1,338 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+var (
+	_ resource.Resource                   = &TypedRecordResource{}
+	_ resource.ResourceWithImportState    = &TypedRecordResource{}
+	_ resource.ResourceWithConfigure      = &TypedRecordResource{}
+	_ resource.ResourceWithValidateConfig = &TypedRecordResource{}
+)
+
+func NewMXRecordResource() resource.Resource {
+	return &TypedRecordResource{recordType: mxRecordType}
+}
+
+func NewSRVRecordResource() resource.Resource {
+	return &TypedRecordResource{recordType: srvRecordType}
+}
+
+func NewCAARecordResource() resource.Resource {
+	return &TypedRecordResource{recordType: caaRecordType}
+}
+
+func NewTXTRecordResource() resource.Resource {
+	return &TypedRecordResource{recordType: txtRecordType}
+}
+
+func NewTLSARecordResource() resource.Resource {
+	return &TypedRecordResource{recordType: tlsaRecordType}
+}
+
+// TypedRecordResource manages an rrset of a single type like pdns_record, but
+// with structured attributes for the fields of the records instead of their
+// content. The recordType renders the attributes to the content and parses
+// them back.
+type TypedRecordResource struct {
+	providerData *PDNSProviderData
+	recordType   typedRecordType
+}
+
+type TypedRecordResourceModel struct {
+	Records types.List   `tfsdk:"records"`
+	Zone    types.String `tfsdk:"zone"`
+	Name    types.String `tfsdk:"name"`
+	TTL     types.Int64  `tfsdk:"ttl"`
+}
+
+// typedRecordType describes the records of a TypedRecordResource.
+type typedRecordType struct {
+	rrType      string
+	description string
+	attributes  map[string]schema.Attribute
+	render      func(record map[string]attr.Value) string
+	parse       func(content string) (map[string]attr.Value, error)
+}
+
+func (t typedRecordType) AttributeTypes() map[string]attr.Type {
+	return lo.MapValues(t.attributes, func(value schema.Attribute, key string) attr.Type {
+		return value.GetType()
+	})
+}
+
+func (r *TypedRecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + strings.ToLower(r.recordType.rrType) + "_record"
+}
+
+func (r *TypedRecordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: r.recordType.description,
+
+		Attributes: map[string]schema.Attribute{
+			"zone": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone in which the record should be created. The name must end with a dot `.`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`\.$`), "Name must end with a dot"),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "LValue of the record (name). This value will be added as prefix to the name. Supports chaining by dot e.g. `sub.test` is a valid value.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "TTL of the record",
+				Computed:            true,
+				Default:             int64default.StaticInt64(1800),
+			},
+			"records": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: fmt.Sprintf("The %s records of the rrset. Records disabled outside of Terraform are not listed, so they show up as a change.", r.recordType.rrType),
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: r.recordType.attributes,
+				},
+			},
+		},
+	}
+}
+
+func (r *TypedRecordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*PDNSProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError("Parse Error", "Failed to parse provider data")
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// ValidateConfig checks the rendered content of every record like
+// pdns_record does, for the fields the attribute validators can not check on
+// their own.
+func (r *TypedRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TypedRecordResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, element := range data.Records.Elements() {
+		record, ok := element.(types.Object)
+		if !ok || record.IsUnknown() || record.IsNull() || lo.ContainsBy(lo.Values(record.Attributes()), func(item attr.Value) bool {
+			return item.IsUnknown()
+		}) {
+			continue
+		}
+
+		content := r.recordType.render(record.Attributes())
+		if err := validateRecordContent(r.recordType.rrType, content); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records").AtListIndex(i),
+				"Invalid Record Content",
+				fmt.Sprintf("The %s record %q is invalid: %s.", r.recordType.rrType, content, err),
+			)
+		}
+	}
+}
+
+func (r *TypedRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TypedRecordResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := replaceRrset(ctx, r.providerData, data.Zone.ValueString(), data.Name.ValueString(), r.recordType.rrType, data.TTL.ValueInt64(), r.recordsFromModel(data), nil)
+	if handleRecordClientError(&resp.Diagnostics, err, typedRecordErrorAttributes) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TypedRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TypedRecordResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rrset, found, err := readRrset(ctx, r.providerData, data.Zone.ValueString(), data.Name.ValueString(), r.recordType.rrType)
+	if handleRecordClientError(&resp.Diagnostics, err, typedRecordErrorAttributes) {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.TTL = types.Int64Value(rrset.TTL)
+
+	records, diags := r.recordsToList(data, rrset.Records)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Records = records
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TypedRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan TypedRecordResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := replaceRrset(ctx, r.providerData, plan.Zone.ValueString(), plan.Name.ValueString(), r.recordType.rrType, plan.TTL.ValueInt64(), r.recordsFromModel(plan), nil)
+	if handleRecordClientError(&resp.Diagnostics, err, typedRecordErrorAttributes) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TypedRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TypedRecordResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := deleteRrset(ctx, r.providerData, data.Zone.ValueString(), data.Name.ValueString(), r.recordType.rrType)
+	handleRecordClientError(&resp.Diagnostics, err, typedRecordErrorAttributes)
+}
+
+// ImportState accepts either `<zone>:<name>` (e.g. `example.com.:www`) or the
+// fully qualified name (e.g. `www.example.com.`), the type is given by the
+// resource.
+func (r *TypedRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	zone, name, diags := resolveRecordName(ctx, r.providerData, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if zone == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID like 'example.com.:www' or 'www.example.com.', got '%s'", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), zone)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// recordsFromModel renders the configured records to their content.
+func (r *TypedRecordResource) recordsFromModel(data TypedRecordResourceModel) []pdns_client.Record {
+	return lo.FilterMap(data.Records.Elements(), func(item attr.Value, index int) (pdns_client.Record, bool) {
+		record, ok := item.(types.Object)
+		if !ok {
+			return pdns_client.Record{}, false
+		}
+		return pdns_client.Record{
+			Content: r.recordType.render(record.Attributes()),
+		}, true
+	})
+}
+
+// recordsToList parses the enabled records PowerDNS reports. Records whose
+// content is equivalent to one of the prior state keep it, so a normalized
+// hostname or hex string does not show up as a change.
+func (r *TypedRecordResource) recordsToList(data TypedRecordResourceModel, records []pdns_client.Record) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	elementType := types.ObjectType{AttrTypes: r.recordType.AttributeTypes()}
+
+	prior := make(map[string]attr.Value, len(data.Records.Elements()))
+	for _, element := range data.Records.Elements() {
+		if record, ok := element.(types.Object); ok {
+			prior[r.recordType.render(record.Attributes())] = element
+		}
+	}
+	priorContents := lo.Keys(prior)
+
+	elements := make([]attr.Value, 0, len(records))
+	for _, record := range records {
+		if record.Disabled {
+			continue
+		}
+
+		if element, ok := prior[equivalentRecordContent(r.recordType.rrType, priorContents, record.Content)]; ok {
+			elements = append(elements, element)
+			continue
+		}
+
+		attributes, err := r.recordType.parse(record.Content)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("records"),
+				"Unexpected Record Content",
+				fmt.Sprintf("PowerDNS reported the %s record %q, which could not be parsed: %s.", r.recordType.rrType, record.Content, err),
+			)
+			return types.ListNull(elementType), diags
+		}
+
+		element, d := types.ObjectValue(elementType.AttrTypes, attributes)
+		diags.Append(d...)
+		if diags.HasError() {
+			return types.ListNull(elementType), diags
+		}
+		elements = append(elements, element)
+	}
+
+	list, d := types.ListValue(elementType, elements)
+	diags.Append(d...)
+	return list, diags
+}
@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+var (
+	_ datasource.DataSource              = &ZoneDataSource{}
+	_ datasource.DataSourceWithConfigure = &ZoneDataSource{}
+)
+
+func NewZoneDataSource() datasource.DataSource {
+	return &ZoneDataSource{}
+}
+
+type ZoneDataSource struct {
+	providerData *PDNSProviderData
+}
+
+type ZoneDataSourceModel struct {
+	Name           types.String `tfsdk:"name"`
+	IncludeRrsets  types.Bool   `tfsdk:"include_rrsets"`
+	Kind           types.String `tfsdk:"kind"`
+	Serial         types.Int64  `tfsdk:"serial"`
+	NotifiedSerial types.Int64  `tfsdk:"notified_serial"`
+	DNSSec         types.Bool   `tfsdk:"dnssec"`
+	Nameservers    types.List   `tfsdk:"nameservers"`
+	Masters        types.List   `tfsdk:"masters"`
+	Account        types.String `tfsdk:"account"`
+	Catalog        types.String `tfsdk:"catalog"`
+	SOA            types.Object `tfsdk:"soa"`
+	Rrsets         types.List   `tfsdk:"rrsets"`
+}
+
+type SOADataModel struct {
+	MName   types.String `tfsdk:"mname"`
+	RName   types.String `tfsdk:"rname"`
+	Serial  types.Int64  `tfsdk:"serial"`
+	Refresh types.Int64  `tfsdk:"refresh"`
+	Retry   types.Int64  `tfsdk:"retry"`
+	Expire  types.Int64  `tfsdk:"expire"`
+	TTL     types.Int64  `tfsdk:"ttl"`
+}
+
+func (m SOADataModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"mname":   types.StringType,
+		"rname":   types.StringType,
+		"serial":  types.Int64Type,
+		"refresh": types.Int64Type,
+		"retry":   types.Int64Type,
+		"expire":  types.Int64Type,
+		"ttl":     types.Int64Type,
+	}
+}
+
+func (d *ZoneDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone"
+}
+
+func (d *ZoneDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing PowerDNS zone, e.g. one managed in another Terraform state.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The Name of the zone. Must end with a dot",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`\.$`), "Name must end with a dot"),
+				},
+			},
+			"include_rrsets": schema.BoolAttribute{
+				MarkdownDescription: "Whether to expose all rrsets of the zone in `rrsets`. Defaults to `false`.",
+				Optional:            true,
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "The zone kind, one of `Native`, `Master`, `Slave`, `Producer` or `Consumer`.",
+				Computed:            true,
+			},
+			"serial": schema.Int64Attribute{
+				MarkdownDescription: "The current serial of the zone",
+				Computed:            true,
+			},
+			"notified_serial": schema.Int64Attribute{
+				MarkdownDescription: "The serial of the zone that was last sent to the secondaries with a NOTIFY",
+				Computed:            true,
+			},
+			"dnssec": schema.BoolAttribute{
+				MarkdownDescription: "Whether or not this zone is DNSSEC signed",
+				Computed:            true,
+			},
+			"nameservers": schema.ListAttribute{
+				MarkdownDescription: "The fully qualified hostnames of the apex NS records",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"masters": schema.ListAttribute{
+				MarkdownDescription: "The primaries a secondary zone is transferred from",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"account": schema.StringAttribute{
+				MarkdownDescription: "The account that owns the zone",
+				Computed:            true,
+			},
+			"catalog": schema.StringAttribute{
+				MarkdownDescription: "The catalog zone the zone is a member of",
+				Computed:            true,
+			},
+			"soa": schema.SingleNestedAttribute{
+				MarkdownDescription: "The fields of the apex SOA record. Null if the zone has none, e.g. a secondary zone before its first transfer.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"mname": schema.StringAttribute{
+						MarkdownDescription: "The primary nameserver",
+						Computed:            true,
+					},
+					"rname": schema.StringAttribute{
+						MarkdownDescription: "The administrator's email address in DNS notation",
+						Computed:            true,
+					},
+					"serial": schema.Int64Attribute{
+						MarkdownDescription: "The serial of the SOA record",
+						Computed:            true,
+					},
+					"refresh": schema.Int64Attribute{
+						MarkdownDescription: "The refresh interval in seconds",
+						Computed:            true,
+					},
+					"retry": schema.Int64Attribute{
+						MarkdownDescription: "The retry interval in seconds",
+						Computed:            true,
+					},
+					"expire": schema.Int64Attribute{
+						MarkdownDescription: "The expiry time in seconds",
+						Computed:            true,
+					},
+					"ttl": schema.Int64Attribute{
+						MarkdownDescription: "The minimum (negative caching) TTL in seconds",
+						Computed:            true,
+					},
+				},
+			},
+			"rrsets": schema.ListNestedAttribute{
+				MarkdownDescription: "All rrsets of the zone. Only set if `include_rrsets` is `true`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The fully qualified name of the rrset",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the rrset",
+							Computed:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "The TTL of the rrset",
+							Computed:            true,
+						},
+						"records":  recordsDataSourceAttribute(),
+						"comments": commentsDataSourceAttribute(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ZoneDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*PDNSProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError("Parse Error", "Failed to parse provider data")
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ZoneDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := d.providerData.pdnsClient.GetZone(ctx, data.Name.ValueString(), true, "")
+	if handleClientError(&resp.Diagnostics, err) {
+		return
+	}
+
+	data.Kind = types.StringValue(zone.Kind)
+	data.Serial = types.Int64Value(zone.Serial)
+	data.NotifiedSerial = types.Int64Value(zone.NotifiedSerial)
+	data.DNSSec = types.BoolValue(zone.Dnssec)
+	data.Account = types.StringValue(zone.Account)
+	data.Catalog = types.StringValue(zone.Catalog)
+
+	masters, diags := types.ListValueFrom(ctx, types.StringType, lo.Ternary(zone.Masters == nil, []string{}, zone.Masters))
+	resp.Diagnostics.Append(diags...)
+
+	nameservers := make([]string, 0)
+	if nsRrset, isFound := lo.Find(zone.Rrsets, func(item pdns_client.Rrset) bool {
+		return item.Type == "NS" && item.Name == zone.Name
+	}); isFound {
+		nameservers = lo.Map(nsRrset.Records, func(item pdns_client.Record, index int) string {
+			return item.Content
+		})
+	}
+	nameserverList, diags := types.ListValueFrom(ctx, types.StringType, nameservers)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Masters = masters
+	data.Nameservers = nameserverList
+
+	data.SOA = types.ObjectNull(SOADataModel{}.AttributeTypes())
+	if soaRrset, isFound := lo.Find(zone.Rrsets, func(item pdns_client.Rrset) bool {
+		return item.Type == "SOA" && item.Name == zone.Name && len(item.Records) > 0
+	}); isFound {
+		soa, err := ParseSOAContent(soaRrset.Records[0].Content)
+		if err != nil {
+			resp.Diagnostics.AddError("Parsing Error", fmt.Sprintf("Failed to parse SOA record: %s", err.Error()))
+			return
+		}
+
+		soaModel := SOADataModel{
+			MName:   types.StringValue(soa.MName),
+			RName:   types.StringValue(soa.RName),
+			Serial:  types.Int64Value(soa.Serial),
+			Refresh: types.Int64Value(soa.Refresh),
+			Retry:   types.Int64Value(soa.Retry),
+			Expire:  types.Int64Value(soa.Expire),
+			TTL:     types.Int64Value(soa.Minimum),
+		}
+
+		objectValue, diags := types.ObjectValueFrom(ctx, soaModel.AttributeTypes(), soaModel)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+		data.SOA = objectValue
+	}
+
+	if data.IncludeRrsets.ValueBool() {
+		rrsets, diags := rrsetsToList(ctx, zone.Rrsets)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+		data.Rrsets = rrsets
+	} else {
+		data.Rrsets = types.ListNull(types.ObjectType{AttrTypes: RrsetModel{}.AttributeTypes()})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,74 @@
+package provider_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/acctest"
+)
+
+func TestAccZoneDataSource(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	if _, err := d.Create("pdns_zone", testZoneConfig(10800)); err != nil {
+		t.Fatalf("create zone: %s", err)
+	}
+	if _, err := d.Create("pdns_record", testRecordConfig("10.0.0.1")); err != nil {
+		t.Fatalf("create record: %s", err)
+	}
+	zone, _ := d.Server.Zone("example.com.")
+
+	state, err := d.ReadDataSource("pdns_zone", map[string]any{"name": "example.com."})
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if got := acctest.StringAttribute(state, "kind"); got != "Native" {
+		t.Errorf("kind = %q, want Native", got)
+	}
+	if got := acctest.Int64Attribute(state, "serial"); got != zone.Serial {
+		t.Errorf("serial = %d, want %d", got, zone.Serial)
+	}
+	if got, want := acctest.StringsAttribute(state, "nameservers"), []string{"ns1.example.com.", "ns2.example.com."}; !slices.Equal(got, want) {
+		t.Errorf("nameservers = %q, want %q", got, want)
+	}
+	if got := acctest.StringsAttribute(state, "masters"); got == nil || len(got) != 0 {
+		t.Errorf("masters = %q, want an empty list", got)
+	}
+
+	soa := acctest.Attribute(state, "soa")
+	if got := acctest.StringAttribute(soa, "mname"); got != "ns1.example.com." {
+		t.Errorf("soa.mname = %q, want ns1.example.com.", got)
+	}
+	if got := acctest.StringAttribute(soa, "rname"); got != "hostmaster.example.com." {
+		t.Errorf("soa.rname = %q, want hostmaster.example.com.", got)
+	}
+	if got := acctest.Int64Attribute(soa, "serial"); got != zone.Serial {
+		t.Errorf("soa.serial = %d, want %d", got, zone.Serial)
+	}
+	if got := acctest.Int64Attribute(soa, "refresh"); got != 10800 {
+		t.Errorf("soa.refresh = %d, want 10800", got)
+	}
+	if !acctest.Attribute(state, "rrsets").IsNull() {
+		t.Error("rrsets are set without include_rrsets")
+	}
+
+	state, err = d.ReadDataSource("pdns_zone", map[string]any{"name": "example.com.", "include_rrsets": true})
+	if err != nil {
+		t.Fatalf("read with rrsets: %s", err)
+	}
+	rrsets := acctest.ListAttribute(state, "rrsets")
+	www := slices.IndexFunc(rrsets, func(rrset tftypes.Value) bool {
+		return acctest.StringAttribute(rrset, "name") == "www.example.com." && acctest.StringAttribute(rrset, "type") == "A"
+	})
+	if www < 0 {
+		t.Fatalf("rrsets do not contain www.example.com./A: %s", rrsets)
+	}
+	if got := acctest.Int64Attribute(rrsets[www], "ttl"); got != 300 {
+		t.Errorf("ttl of www.example.com./A = %d, want 300", got)
+	}
+
+	if _, err := d.ReadDataSource("pdns_zone", map[string]any{"name": "example.org."}); err == nil {
+		t.Error("reading a missing zone succeeded, want error")
+	}
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+var (
+	_ datasource.DataSource              = &ZoneDNSSecDSDataSource{}
+	_ datasource.DataSourceWithConfigure = &ZoneDNSSecDSDataSource{}
+)
+
+// DS digest types, see https://www.iana.org/assignments/ds-rr-types
+const (
+	dsDigestSHA256 = 2
+	dsDigestSHA384 = 4
+)
+
+func NewZoneDNSSecDSDataSource() datasource.DataSource {
+	return &ZoneDNSSecDSDataSource{}
+}
+
+type ZoneDNSSecDSDataSource struct {
+	providerData *PDNSProviderData
+}
+
+type ZoneDNSSecDSDataSourceModel struct {
+	Zone types.String `tfsdk:"zone"`
+	Keys types.List   `tfsdk:"keys"`
+	DS   types.List   `tfsdk:"ds"`
+}
+
+type DSKeyModel struct {
+	KeyID           types.Int64  `tfsdk:"key_id"`
+	KeyType         types.String `tfsdk:"key_type"`
+	KeyTag          types.Int64  `tfsdk:"key_tag"`
+	Algorithm       types.String `tfsdk:"algorithm"`
+	AlgorithmNumber types.Int64  `tfsdk:"algorithm_number"`
+	Flags           types.Int64  `tfsdk:"flags"`
+	PublicKey       types.String `tfsdk:"public_key"`
+	DNSKey          types.String `tfsdk:"dnskey"`
+	DSSHA256        types.String `tfsdk:"ds_sha256"`
+	DSSHA384        types.String `tfsdk:"ds_sha384"`
+	DS              types.List   `tfsdk:"ds"`
+}
+
+func (m DSKeyModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"key_id":           types.Int64Type,
+		"key_type":         types.StringType,
+		"key_tag":          types.Int64Type,
+		"algorithm":        types.StringType,
+		"algorithm_number": types.Int64Type,
+		"flags":            types.Int64Type,
+		"public_key":       types.StringType,
+		"dnskey":           types.StringType,
+		"ds_sha256":        types.StringType,
+		"ds_sha384":        types.StringType,
+		"ds":               types.ListType{ElemType: types.StringType},
+	}
+}
+
+// dsCryptokeys returns the active keys of cryptokeys that have DS records,
+// sorted by id. Zone signing keys have none.
+func dsCryptokeys(cryptokeys []pdns_client.Cryptokey) []pdns_client.Cryptokey {
+	cryptokeys = lo.Filter(cryptokeys, func(item pdns_client.Cryptokey, index int) bool {
+		return item.Active && len(item.DS) > 0
+	})
+	slices.SortFunc(cryptokeys, func(a, b pdns_client.Cryptokey) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return cryptokeys
+}
+
+// setDS sets the key tag and the DS contents with a SHA-256 and SHA-384
+// digest from the DS contents PowerDNS provides for the key. Digest types
+// without an attribute of their own are only part of `ds`.
+func (m *DSKeyModel) setDS(contents []string) error {
+	for _, content := range contents {
+		ds, err := ParseDSContent(content)
+		if err != nil {
+			return err
+		}
+
+		m.KeyTag = types.Int64Value(ds.KeyTag)
+		switch ds.DigestType {
+		case dsDigestSHA256:
+			m.DSSHA256 = types.StringValue(content)
+		case dsDigestSHA384:
+			m.DSSHA384 = types.StringValue(content)
+		}
+	}
+	return nil
+}
+
+func (d *ZoneDNSSecDSDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_dnssec_ds"
+}
+
+func (d *ZoneDNSSecDSDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the DS records of the active key signing keys (`ksk` and `csk`) of a DNSSEC signed zone, to be published in the parent zone or handed to a registrar.",
+		Attributes: map[string]schema.Attribute{
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The Name of the zone. Must end with a dot",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`\.$`), "Name must end with a dot"),
+				},
+			},
+			"ds": schema.ListAttribute{
+				MarkdownDescription: "The contents of all DS records of all keys, e.g. for the `records` of a `pdns_record` of type `DS` in the parent zone.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "The active key signing keys of the zone, sorted by `key_id`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key_id": schema.Int64Attribute{
+							MarkdownDescription: "The id PowerDNS assigned to the key",
+							Computed:            true,
+						},
+						"key_type": schema.StringAttribute{
+							MarkdownDescription: "The role of the key, `ksk` or `csk`",
+							Computed:            true,
+						},
+						"key_tag": schema.Int64Attribute{
+							MarkdownDescription: "The key tag identifying the key in DS records",
+							Computed:            true,
+						},
+						"algorithm": schema.StringAttribute{
+							MarkdownDescription: "The name of the DNSSEC algorithm, e.g. `ECDSAP256SHA256`",
+							Computed:            true,
+						},
+						"algorithm_number": schema.Int64Attribute{
+							MarkdownDescription: "The number of the DNSSEC algorithm, e.g. `13`",
+							Computed:            true,
+						},
+						"flags": schema.Int64Attribute{
+							MarkdownDescription: "The flags of the DNSKEY record, `257` for a key signing key",
+							Computed:            true,
+						},
+						"public_key": schema.StringAttribute{
+							MarkdownDescription: "The base64 encoded public key",
+							Computed:            true,
+						},
+						"dnskey": schema.StringAttribute{
+							MarkdownDescription: "The DNSKEY record content of the key",
+							Computed:            true,
+						},
+						"ds_sha256": schema.StringAttribute{
+							MarkdownDescription: "The DS record content using a SHA-256 digest (digest type 2)",
+							Computed:            true,
+						},
+						"ds_sha384": schema.StringAttribute{
+							MarkdownDescription: "The DS record content using a SHA-384 digest (digest type 4). Null if the server does not provide it.",
+							Computed:            true,
+						},
+						"ds": schema.ListAttribute{
+							MarkdownDescription: "All DS record contents PowerDNS provides for the key",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ZoneDNSSecDSDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*PDNSProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError("Parse Error", "Failed to parse provider data")
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *ZoneDNSSecDSDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ZoneDNSSecDSDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cryptokeys, err := d.providerData.pdnsClient.ListCryptokeys(ctx, data.Zone.ValueString())
+	if handleClientError(&resp.Diagnostics, err) {
+		return
+	}
+
+	cryptokeys = dsCryptokeys(cryptokeys)
+
+	keys := make([]DSKeyModel, 0, len(cryptokeys))
+	for _, cryptokey := range cryptokeys {
+		dnskey, err := ParseDNSKeyContent(cryptokey.DNSKey)
+		if err != nil {
+			resp.Diagnostics.AddError("Parsing Error", fmt.Sprintf("Failed to parse DNSKEY of cryptokey %d: %s", cryptokey.ID, err.Error()))
+			return
+		}
+
+		key := DSKeyModel{
+			KeyID:           types.Int64Value(cryptokey.ID),
+			KeyType:         types.StringValue(cryptokey.KeyType),
+			Algorithm:       types.StringValue(cryptokey.Algorithm),
+			AlgorithmNumber: types.Int64Value(dnskey.Algorithm),
+			Flags:           types.Int64Value(dnskey.Flags),
+			PublicKey:       types.StringValue(dnskey.PublicKey),
+			DNSKey:          types.StringValue(cryptokey.DNSKey),
+			DSSHA256:        types.StringNull(),
+			DSSHA384:        types.StringNull(),
+		}
+
+		if err := key.setDS(cryptokey.DS); err != nil {
+			resp.Diagnostics.AddError("Parsing Error", fmt.Sprintf("Failed to parse DS of cryptokey %d: %s", cryptokey.ID, err.Error()))
+			return
+		}
+
+		ds, diags := types.ListValueFrom(ctx, types.StringType, cryptokey.DS)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+		key.DS = ds
+
+		keys = append(keys, key)
+	}
+
+	keyList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: DSKeyModel{}.AttributeTypes()}, keys)
+	resp.Diagnostics.Append(diags...)
+
+	dsList, diags := types.ListValueFrom(ctx, types.StringType, lo.FlatMap(cryptokeys, func(item pdns_client.Cryptokey, index int) []string {
+		return item.DS
+	}))
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Keys = keyList
+	data.DS = dsList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
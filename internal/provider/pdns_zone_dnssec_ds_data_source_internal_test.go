@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+func TestDSCryptokeys(t *testing.T) {
+	cryptokeys := []pdns_client.Cryptokey{
+		{ID: 7, KeyType: "ksk", Active: true, DS: []string{"7 13 2 aa"}},
+		{ID: 2, KeyType: "zsk", Active: true},
+		{ID: 5, KeyType: "csk", Active: false, DS: []string{"5 13 2 bb"}},
+		{ID: 3, KeyType: "csk", Active: true, DS: []string{"3 13 2 cc"}},
+		{ID: 12, KeyType: "ksk", Active: true, DS: []string{"12 13 2 dd"}},
+	}
+
+	var ids []int64
+	for _, cryptokey := range dsCryptokeys(cryptokeys) {
+		ids = append(ids, cryptokey.ID)
+	}
+	if want := []int64{3, 7, 12}; !slices.Equal(ids, want) {
+		t.Errorf("key ids = %v, want the active keys with DS sorted by id %v", ids, want)
+	}
+}
+
+func TestDSKeyModel_setDS(t *testing.T) {
+	const (
+		sha1   = "12345 13 1 0123456789abcdef0123456789abcdef01234567"
+		sha256 = "12345 13 2 0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+		gost   = "12345 13 3 0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+		sha384 = "12345 13 4 0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	)
+
+	tests := map[string]struct {
+		contents   []string
+		wantSHA256 types.String
+		wantSHA384 types.String
+		wantError  bool
+	}{
+		"SHA-256 and SHA-384": {
+			contents:   []string{sha256, sha384},
+			wantSHA256: types.StringValue(sha256),
+			wantSHA384: types.StringValue(sha384),
+		},
+		"order does not matter": {
+			contents:   []string{sha384, sha1, sha256},
+			wantSHA256: types.StringValue(sha256),
+			wantSHA384: types.StringValue(sha384),
+		},
+		"only SHA-256": {
+			contents:   []string{sha256},
+			wantSHA256: types.StringValue(sha256),
+			wantSHA384: types.StringNull(),
+		},
+		"other digest types only": {
+			contents:   []string{sha1, gost},
+			wantSHA256: types.StringNull(),
+			wantSHA384: types.StringNull(),
+		},
+		"invalid content": {
+			contents:  []string{sha256, "12345 13 SHA256 0123"},
+			wantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			key := DSKeyModel{DSSHA256: types.StringNull(), DSSHA384: types.StringNull()}
+			err := key.setDS(test.contents)
+			if test.wantError {
+				if err == nil {
+					t.Error("setDS() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("setDS() failed: %s", err)
+			}
+
+			if key.KeyTag.ValueInt64() != 12345 {
+				t.Errorf("key tag = %s, want 12345", key.KeyTag)
+			}
+			if !key.DSSHA256.Equal(test.wantSHA256) {
+				t.Errorf("ds_sha256 = %s, want %s", key.DSSHA256, test.wantSHA256)
+			}
+			if !key.DSSHA384.Equal(test.wantSHA384) {
+				t.Errorf("ds_sha384 = %s, want %s", key.DSSHA384, test.wantSHA384)
+			}
+		})
+	}
+}
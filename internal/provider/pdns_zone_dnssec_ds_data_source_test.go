@@ -0,0 +1,68 @@
+package provider_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/acctest"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+func TestAccZoneDNSSecDSDataSource(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	if _, err := d.Create("pdns_zone", testZoneConfig(10800)); err != nil {
+		t.Fatalf("create zone: %s", err)
+	}
+	for _, config := range []map[string]any{
+		{"zone": "example.com.", "key_type": "ksk", "active": true},
+		{"zone": "example.com.", "key_type": "zsk", "active": true},
+		{"zone": "example.com.", "key_type": "csk", "active": false},
+		{"zone": "example.com.", "key_type": "csk", "active": true, "algorithm": "ED25519"},
+	} {
+		if _, err := d.Create("pdns_cryptokey", config); err != nil {
+			t.Fatalf("create cryptokey: %s", err)
+		}
+	}
+
+	state, err := d.ReadDataSource("pdns_zone_dnssec_ds", map[string]any{"zone": "example.com."})
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+
+	keys := acctest.ListAttribute(state, "keys")
+	var ids []int64
+	for _, key := range keys {
+		ids = append(ids, acctest.Int64Attribute(key, "key_id"))
+	}
+	if want := []int64{1, 4}; !slices.Equal(ids, want) {
+		t.Fatalf("key ids = %v, want the active ksk and csk %v", ids, want)
+	}
+
+	server := d.Server.Cryptokeys("example.com.")
+	var wantDS []string
+	for i, key := range keys {
+		cryptokey := server[slices.IndexFunc(server, func(item pdns_client.Cryptokey) bool { return item.ID == ids[i] })]
+		wantDS = append(wantDS, cryptokey.DS...)
+
+		if got := acctest.StringAttribute(key, "dnskey"); got != cryptokey.DNSKey {
+			t.Errorf("keys[%d].dnskey = %q, want %q", i, got, cryptokey.DNSKey)
+		}
+		if got := acctest.StringAttribute(key, "ds_sha256"); !strings.Contains(got, " 2 ") {
+			t.Errorf("keys[%d].ds_sha256 = %q, want the DS with digest type 2", i, got)
+		}
+		if got := acctest.StringAttribute(key, "ds_sha384"); !strings.Contains(got, " 4 ") {
+			t.Errorf("keys[%d].ds_sha384 = %q, want the DS with digest type 4", i, got)
+		}
+		if got := acctest.Int64Attribute(key, "flags"); got != 257 {
+			t.Errorf("keys[%d].flags = %d, want 257", i, got)
+		}
+	}
+	if got := acctest.StringAttribute(keys[1], "algorithm"); got != "ED25519" {
+		t.Errorf("keys[1].algorithm = %q, want ED25519", got)
+	}
+	if got := acctest.StringsAttribute(state, "ds"); !slices.Equal(got, wantDS) {
+		t.Errorf("ds = %q, want %q", got, wantDS)
+	}
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+var (
+	_ resource.Resource                = &ZoneMetadataResource{}
+	_ resource.ResourceWithImportState = &ZoneMetadataResource{}
+	_ resource.ResourceWithConfigure   = &ZoneMetadataResource{}
+)
+
+func NewZoneMetadataResource() resource.Resource {
+	return &ZoneMetadataResource{}
+}
+
+type ZoneMetadataResource struct {
+	providerData *PDNSProviderData
+}
+
+type ZoneMetadataResourceModel struct {
+	Zone   types.String `tfsdk:"zone"`
+	Kind   types.String `tfsdk:"kind"`
+	Values types.List   `tfsdk:"values"`
+}
+
+func (r *ZoneMetadataResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_metadata"
+}
+
+func (r *ZoneMetadataResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages all values of one metadata kind of a PowerDNS zone, e.g. `ALLOW-AXFR-FROM` or `ALSO-NOTIFY`. See the [PowerDNS documentation](https://doc.powerdns.com/authoritative/domainmetadata.html) for the available kinds.",
+
+		Attributes: map[string]schema.Attribute{
+			"zone": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone the metadata belongs to. The name must end with a dot `.`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`\.$`), "Name must end with a dot"),
+				},
+			},
+			"kind": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The metadata kind, e.g. `ALLOW-AXFR-FROM`, or a custom kind starting with `X-`. Kinds PowerDNS does not allow to change through the API, like `TSIG-ALLOW-AXFR` or `NSEC3PARAM`, are rejected; most of them are attributes of `pdns_zone`. `SOA-EDIT` is also set by the `soa_edit` attribute of `pdns_zone`, manage it in only one place.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					metadataKindValidator{},
+				},
+			},
+			"values": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The values of the metadata kind, e.g. the addresses allowed to transfer the zone for `ALLOW-AXFR-FROM`.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *ZoneMetadataResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*PDNSProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError("Parse Error", "Failed to parse provider data")
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *ZoneMetadataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ZoneMetadataResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setMetadata(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneMetadataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ZoneMetadataResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadata, err := r.providerData.pdnsClient.GetMetadata(ctx, data.Zone.ValueString(), data.Kind.ValueString())
+
+	// PowerDNS reports a kind without values instead of a 404.
+	var notFoundError *pdns_client.PDNSZoneNotFoundError
+	if errors.As(err, &notFoundError) || (err == nil && len(metadata.Metadata) == 0) {
+		tflog.Warn(ctx, "Zone metadata was deleted outside of Terraform, removing it from state", map[string]any{
+			"zone": data.Zone.ValueString(),
+			"kind": data.Kind.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if handleClientError(&resp.Diagnostics, err) {
+		return
+	}
+
+	values, diags := types.ListValueFrom(ctx, types.StringType, metadata.Metadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Values = values
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneMetadataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ZoneMetadataResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setMetadata(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ZoneMetadataResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ZoneMetadataResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.providerData.pdnsClient.DeleteMetadata(ctx, data.Zone.ValueString(), data.Kind.ValueString())
+	handleClientError(&resp.Diagnostics, err)
+}
+
+// ImportState accepts `<zone>:<kind>`, e.g. `example.com.:ALLOW-AXFR-FROM`.
+func (r *ZoneMetadataResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	zone, kind, found := strings.Cut(req.ID, ":")
+	if !found || zone == "" || kind == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID like 'example.com.:ALLOW-AXFR-FROM', got '%s'", req.ID),
+		)
+		return
+	}
+
+	if !strings.HasSuffix(zone, ".") {
+		zone += "."
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), zone)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kind"), kind)...)
+}
+
+// setMetadata replaces the values of the kind in data with the configured
+// ones, taking over values that were set outside of Terraform.
+func (r *ZoneMetadataResource) setMetadata(ctx context.Context, data *ZoneMetadataResourceModel, diags *diag.Diagnostics) {
+	values, valueDiags := stringsFromList(ctx, data.Values)
+	diags.Append(valueDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	metadata, err := r.providerData.pdnsClient.SetMetadata(ctx, data.Zone.ValueString(), data.Kind.ValueString(), values)
+	if handleClientError(diags, err) {
+		return
+	}
+
+	list, valueDiags := types.ListValueFrom(ctx, types.StringType, metadata.Metadata)
+	diags.Append(valueDiags...)
+	data.Values = list
+}
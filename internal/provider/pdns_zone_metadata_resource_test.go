@@ -0,0 +1,147 @@
+package provider_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/acctest"
+)
+
+func TestAccZoneMetadataResource(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	if _, err := d.Create("pdns_zone", testZoneConfig(10800)); err != nil {
+		t.Fatalf("create zone: %s", err)
+	}
+
+	config := map[string]any{"zone": "example.com.", "kind": "ALLOW-AXFR-FROM", "values": []any{"192.0.2.0/24"}}
+	state, err := d.Create("pdns_zone_metadata", config)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	if values := d.Server.Metadata("example.com.", "ALLOW-AXFR-FROM"); !slices.Equal(values, []string{"192.0.2.0/24"}) {
+		t.Errorf("ALLOW-AXFR-FROM = %q, want [192.0.2.0/24]", values)
+	}
+
+	read, err := d.Read("pdns_zone_metadata", state)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if !read.Equal(state) {
+		t.Errorf("read drifted from state:\n got: %s\nwant: %s", read, state)
+	}
+
+	config["values"] = []any{"192.0.2.0/24", "2001:db8::/32"}
+	updated, err := d.Update("pdns_zone_metadata", state, config)
+	if err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	if values := d.Server.Metadata("example.com.", "ALLOW-AXFR-FROM"); !slices.Equal(values, []string{"192.0.2.0/24", "2001:db8::/32"}) {
+		t.Errorf("ALLOW-AXFR-FROM after update = %q, want [192.0.2.0/24 2001:db8::/32]", values)
+	}
+	if got := acctest.StringsAttribute(updated, "values"); !slices.Equal(got, []string{"192.0.2.0/24", "2001:db8::/32"}) {
+		t.Errorf("values after update = %q, want [192.0.2.0/24 2001:db8::/32]", got)
+	}
+
+	// The trailing dot of the zone may be omitted on import.
+	for _, id := range []string{"example.com.:ALLOW-AXFR-FROM", "example.com:ALLOW-AXFR-FROM"} {
+		imported, err := d.Import("pdns_zone_metadata", id)
+		if err != nil {
+			t.Fatalf("import %s: %s", id, err)
+		}
+		if !imported.Equal(updated) {
+			t.Errorf("import %s differs from state:\n got: %s\nwant: %s", id, imported, updated)
+		}
+		planned, err := d.Plan("pdns_zone_metadata", imported, config)
+		if err != nil {
+			t.Fatalf("plan after import %s: %s", id, err)
+		}
+		if !planned.Equal(imported) {
+			t.Errorf("plan after import %s is not empty:\n got: %s\nwant: %s", id, planned, imported)
+		}
+	}
+
+	if _, err := d.Import("pdns_zone_metadata", "example.com."); err == nil {
+		t.Error("import without a kind succeeded, want error")
+	}
+
+	if err := d.Destroy("pdns_zone_metadata", updated); err != nil {
+		t.Fatalf("destroy: %s", err)
+	}
+	if values := d.Server.Metadata("example.com.", "ALLOW-AXFR-FROM"); len(values) != 0 {
+		t.Errorf("ALLOW-AXFR-FROM after destroy = %q, want none", values)
+	}
+}
+
+func TestAccZoneMetadataResource_removedOutOfBand(t *testing.T) {
+	tests := map[string]func(d *acctest.Driver){
+		"empty metadata": func(d *acctest.Driver) { d.Server.SetMetadata("example.com.", "ALLOW-AXFR-FROM", nil) },
+		"zone removed":   func(d *acctest.Driver) { d.Server.RemoveZone("example.com.") },
+	}
+
+	for name, remove := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := acctest.NewDriver(t, nil)
+
+			if _, err := d.Create("pdns_zone", testZoneConfig(10800)); err != nil {
+				t.Fatalf("create zone: %s", err)
+			}
+			state, err := d.Create("pdns_zone_metadata", map[string]any{"zone": "example.com.", "kind": "ALLOW-AXFR-FROM", "values": []any{"192.0.2.0/24"}})
+			if err != nil {
+				t.Fatalf("create: %s", err)
+			}
+
+			remove(d)
+
+			read, err := d.Read("pdns_zone_metadata", state)
+			if err != nil {
+				t.Fatalf("read: %s", err)
+			}
+			if !read.IsNull() {
+				t.Errorf("metadata was not removed from state: %s", read)
+			}
+		})
+	}
+}
+
+func TestAccZoneMetadataResource_readOnlyKinds(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	if _, err := d.Create("pdns_zone", testZoneConfig(10800)); err != nil {
+		t.Fatalf("create zone: %s", err)
+	}
+
+	tests := map[string]string{
+		"SOA-EDIT-API": "`soa_edit_api`",
+		"NSEC3PARAM":   "`nsec3param`",
+	}
+
+	for kind, attribute := range tests {
+		t.Run(kind, func(t *testing.T) {
+			_, err := d.Create("pdns_zone_metadata", map[string]any{"zone": "example.com.", "kind": kind, "values": []any{"x"}})
+			if err == nil {
+				t.Fatal("creating read-only metadata succeeded, want error")
+			}
+			if !strings.Contains(err.Error(), attribute) {
+				t.Errorf("error = %q, want it to point at %s", err, attribute)
+			}
+		})
+	}
+}
+
+func TestAccZoneMetadataResource_soaEdit(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	if _, err := d.Create("pdns_zone", testZoneConfig(10800)); err != nil {
+		t.Fatalf("create zone: %s", err)
+	}
+
+	// Unlike SOA-EDIT-API, PowerDNS allows to change SOA-EDIT as metadata.
+	if _, err := d.Create("pdns_zone_metadata", map[string]any{"zone": "example.com.", "kind": "SOA-EDIT", "values": []any{"INCEPTION-EPOCH"}}); err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	if values := d.Server.Metadata("example.com.", "SOA-EDIT"); len(values) != 1 || values[0] != "INCEPTION-EPOCH" {
+		t.Errorf("SOA-EDIT = %q, want [INCEPTION-EPOCH]", values)
+	}
+}
@@ -6,9 +6,8 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"slices"
 	"strconv"
-	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -18,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -31,9 +31,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &ZoneResource{}
-	_ resource.ResourceWithImportState = &ZoneResource{}
-	_ resource.ResourceWithConfigure   = &ZoneResource{}
+	_ resource.Resource                   = &ZoneResource{}
+	_ resource.ResourceWithImportState    = &ZoneResource{}
+	_ resource.ResourceWithConfigure      = &ZoneResource{}
+	_ resource.ResourceWithValidateConfig = &ZoneResource{}
 )
 
 func NewZoneResource() resource.Resource {
@@ -52,6 +53,18 @@ type ZoneResourceModel struct {
 	Kind        types.String `tfsdk:"kind"`
 	SOA         types.Object `tfsdk:"soa"`
 	DNSSec      types.Bool   `tfsdk:"dnssec"`
+	Nsec3Param  types.String `tfsdk:"nsec3param"`
+	Nsec3Narrow types.Bool   `tfsdk:"nsec3narrow"`
+	Presigned   types.Bool   `tfsdk:"presigned"`
+	APIRectify  types.Bool   `tfsdk:"api_rectify"`
+	Catalog     types.String `tfsdk:"catalog"`
+	SOAEditAPI  types.String `tfsdk:"soa_edit_api"`
+	SOAEdit     types.String `tfsdk:"soa_edit"`
+
+	SerialStrategy types.String `tfsdk:"serial_strategy"`
+
+	MasterTSIGKeyIDs types.List `tfsdk:"master_tsig_key_ids"`
+	SlaveTSIGKeyIDs  types.List `tfsdk:"slave_tsig_key_ids"`
 }
 
 type Nameserver struct {
@@ -59,6 +72,13 @@ type Nameserver struct {
 	Hostname string  `tfsdk:"hostname"`
 }
 
+func (n Nameserver) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"address":  types.StringType,
+		"hostname": types.StringType,
+	}
+}
+
 type SOA struct {
 	RName        string `tfsdk:"rname"`
 	Refresh      int64  `tfsdk:"refresh"`
@@ -105,9 +125,75 @@ func (r *ZoneResource) Metadata(ctx context.Context, req resource.MetadataReques
 
 var IP_REGEX = regexp.MustCompile(`^((([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])$|^(([a-fA-F]|[a-fA-F][a-fA-F0-9\-]*[a-fA-F0-9])\.)*([A-Fa-f]|[A-Fa-f][A-Fa-f0-9\-]*[A-Fa-f0-9])$|^(?:(?:(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):){6})(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):(?:(?:[0-9a-fA-F]{1,4})))|(?:(?:(?:(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9]))\.){3}(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9])))))))|(?:(?:::(?:(?:(?:[0-9a-fA-F]{1,4})):){5})(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):(?:(?:[0-9a-fA-F]{1,4})))|(?:(?:(?:(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9]))\.){3}(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9])))))))|(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})))?::(?:(?:(?:[0-9a-fA-F]{1,4})):){4})(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):(?:(?:[0-9a-fA-F]{1,4})))|(?:(?:(?:(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9]))\.){3}(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9])))))))|(?:(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):){0,1}(?:(?:[0-9a-fA-F]{1,4})))?::(?:(?:(?:[0-9a-fA-F]{1,4})):){3})(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):(?:(?:[0-9a-fA-F]{1,4})))|(?:(?:(?:(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9]))\.){3}(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9])))))))|(?:(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):){0,2}(?:(?:[0-9a-fA-F]{1,4})))?::(?:(?:(?:[0-9a-fA-F]{1,4})):){2})(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):(?:(?:[0-9a-fA-F]{1,4})))|(?:(?:(?:(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9]))\.){3}(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9])))))))|(?:(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):){0,3}(?:(?:[0-9a-fA-F]{1,4})))?::(?:(?:[0-9a-fA-F]{1,4})):)(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):(?:(?:[0-9a-fA-F]{1,4})))|(?:(?:(?:(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9]))\.){3}(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9])))))))|(?:(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):){0,4}(?:(?:[0-9a-fA-F]{1,4})))?::)(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):(?:(?:[0-9a-fA-F]{1,4})))|(?:(?:(?:(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9]))\.){3}(?:(?:25[0-5]|(?:[1-9]|1[0-9]|2[0-4])?[0-9])))))))|(?:(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):){0,5}(?:(?:[0-9a-fA-F]{1,4})))?::)(?:(?:[0-9a-fA-F]{1,4})))|(?:(?:(?:(?:(?:(?:[0-9a-fA-F]{1,4})):){0,6}(?:(?:[0-9a-fA-F]{1,4})))?::)))))$`)
 
-// TODO: Allow for TSIG
-// TODO: Further DNSSec functionality?
-// TODO: Support for other kinds Like slave or master
+// NSEC3PARAM_REGEX matches the NSEC3PARAM content PowerDNS accepts: hash
+// algorithm 1 (SHA-1), the opt-out flag, a 16 bit iteration count and a hex
+// salt of up to 255 bytes or `-` for none.
+var NSEC3PARAM_REGEX = regexp.MustCompile(`^1 [01] ([0-9]|[1-9][0-9]{1,3}|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-5]) (-|([0-9a-fA-F]{2}){1,255})$`)
+
+var tsigKeyIDsValidators = []validator.List{
+	listvalidator.SizeAtLeast(1),
+	listvalidator.UniqueValues(),
+	listvalidator.ValueStringsAre(
+		stringvalidator.RegexMatches(regexp.MustCompile(`\.$`), "TSIG key ids must end with a dot"),
+	),
+}
+
+// serialManagedByPDNS reports whether PowerDNS increases the serial of a zone
+// with the given SOA-EDIT-API kind on every change made through the API.
+func serialManagedByPDNS(soaEditAPI types.String) bool {
+	return soaEditAPI.ValueString() != "" && soaEditAPI.ValueString() != "OFF"
+}
+
+// serialStrategy returns the strategy the provider uses for a serial it owns.
+func serialStrategy(data ZoneResourceModel) string {
+	return lo.Ternary(data.SerialStrategy.IsNull(), "date", data.SerialStrategy.ValueString())
+}
+
+var _ planmodifier.String = soaEditAPIPlanModifier{}
+
+// soaEditAPIPlanModifier turns SOA-EDIT-API off when the provider owns the
+// serial through `serial_strategy` and `soa_edit_api` is not configured, so
+// that PowerDNS does not increase the serial on top of the provider. Once
+// `serial_strategy` is removed again, the serial is handed back to PowerDNS
+// with `DEFAULT` instead of keeping the `OFF` the provider set.
+type soaEditAPIPlanModifier struct{}
+
+func (m soaEditAPIPlanModifier) Description(ctx context.Context) string {
+	return "Sets `soa_edit_api` to `OFF` if `serial_strategy` is set, and back to `DEFAULT` once it is removed."
+}
+
+func (m soaEditAPIPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m soaEditAPIPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var strategy types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("serial_strategy"), &strategy)...)
+	if !strategy.IsNull() {
+		resp.PlanValue = types.StringValue("OFF")
+		return
+	}
+
+	if req.State.Raw.IsNull() || req.StateValue.ValueString() != "OFF" {
+		return
+	}
+
+	var priorStrategy types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("serial_strategy"), &priorStrategy)...)
+	if !priorStrategy.IsNull() {
+		resp.PlanValue = types.StringValue("DEFAULT")
+	}
+}
+
+// isSecondaryKind reports whether zones of kind are transferred from masters
+// instead of being authored.
+func isSecondaryKind(kind string) bool {
+	return kind == "Slave" || kind == "Consumer"
+}
 
 func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
@@ -124,20 +210,73 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"serial": schema.StringAttribute{
-				MarkdownDescription: "The serial of the zone",
+				MarkdownDescription: "The serial of the zone. Managed by PowerDNS according to `soa_edit_api`, or by the provider according to `serial_strategy`.",
 				Optional:            false,
 				Required:            false,
 				Computed:            true,
 			},
 			"dnssec": schema.BoolAttribute{
-				MarkdownDescription: "Whether or not this zone is DNSSEC signed",
+				MarkdownDescription: "Whether or not this zone is DNSSEC signed. Enabling it on a zone without keys makes PowerDNS generate default keys, disabling it removes all keys of the zone.",
 				Optional:            true,
 				Default:             booldefault.StaticBool(false),
 				Computed:            true,
 			},
+			"nsec3param": schema.StringAttribute{
+				MarkdownDescription: "The NSEC3 parameters of the zone as `<algorithm> <flags> <iterations> <salt>`, e.g. `1 0 0 -` for no extra iterations and no salt as recommended by RFC 9276. The zone uses NSEC if unset. Requires `dnssec` or `presigned`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(NSEC3PARAM_REGEX, "Must be '<algorithm> <flags> <iterations> <salt>' with algorithm 1, flags 0 or 1, iterations up to 65535 and a hex salt or '-', e.g. '1 0 0 -'"),
+				},
+			},
+			"nsec3narrow": schema.BoolAttribute{
+				MarkdownDescription: "Whether NSEC3 records are generated on the fly in narrow mode instead of being precomputed. Requires `nsec3param`. Defaults to `false`.",
+				Optional:            true,
+				Default:             booldefault.StaticBool(false),
+				Computed:            true,
+			},
+			"presigned": schema.BoolAttribute{
+				MarkdownDescription: "Whether the zone is presigned, i.e. its signatures are transferred from the primary and served as is. Defaults to `false`.",
+				Optional:            true,
+				Default:             booldefault.StaticBool(false),
+				Computed:            true,
+			},
+			"api_rectify": schema.BoolAttribute{
+				MarkdownDescription: "Whether PowerDNS rectifies the zone after every change made through the API. Defaults to the `default-api-rectify` setting of the server.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"soa_edit_api": schema.StringAttribute{
+				MarkdownDescription: "How PowerDNS increases the serial on changes made through the API, including those of `pdns_record`. One of `DEFAULT`, `INCREASE`, `EPOCH`, `SOA-EDIT`, `SOA-EDIT-INCREASE` or `OFF`. PowerDNS uses `DEFAULT` for new zones if unset, the provider uses `OFF` if `serial_strategy` is set and `DEFAULT` again once `serial_strategy` is removed. With `OFF`, or for an imported zone without it, the provider increases the serial itself according to `serial_strategy`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					soaEditAPIPlanModifier{},
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("DEFAULT", "INCREASE", "EPOCH", "SOA-EDIT", "SOA-EDIT-INCREASE", "OFF"),
+				},
+			},
+			"serial_strategy": schema.StringAttribute{
+				MarkdownDescription: "How the provider increases the serial on changes of `nameservers` and `soa` if the serial is not managed by PowerDNS, see `soa_edit_api`. One of `date` (YYYYMMDDnn, rolling over into the next day after 99 changes), `epoch` (the unix time), `increment` (the serial plus one) or `keep` (never changed, a new zone starts with today's `date` serial). Setting it hands the serial over to the provider. Defaults to `date`. Changes of `pdns_record` do not change a serial owned by the provider.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("date", "epoch", "increment", "keep"),
+				},
+			},
+			"soa_edit": schema.StringAttribute{
+				MarkdownDescription: "How PowerDNS rewrites the serial in answers to SOA queries and zone transfers of a DNSSEC signed zone. One of `INCREMENT-WEEKS`, `INCEPTION-EPOCH`, `INCEPTION-INCREMENT`, `EPOCH` or `NONE`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("INCREMENT-WEEKS", "INCEPTION-EPOCH", "INCEPTION-INCREMENT", "EPOCH", "NONE"),
+				},
+			},
 			"nameservers": schema.ListNestedAttribute{
-				MarkdownDescription: "The nameservers of the Zone",
-				Required:            true,
+				MarkdownDescription: "The nameservers of the Zone. Required unless `kind` is `Slave` or `Consumer`, must not be set for those.",
+				Optional:            true,
 				Validators: []validator.List{
 					listvalidator.SizeAtLeast(1),
 				},
@@ -158,24 +297,55 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"kind": schema.StringAttribute{
-				MarkdownDescription: "The zone kind. One of `Native`, `Master`, `Slave`, `Producer` or `Consumer`. Defaults to `Native`.",
+				MarkdownDescription: "The zone kind. One of `Native`, `Master`, `Slave`, `Producer` or `Consumer`. Defaults to `Native`. Changing between a secondary kind (`Slave` or `Consumer`) and any other kind forces a new resource.",
 				Optional:            true,
 				Default:             stringdefault.StaticString("Native"),
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = isSecondaryKind(req.StateValue.ValueString()) != isSecondaryKind(req.PlanValue.ValueString())
+						},
+						"Changing between a secondary and a primary kind requires a replacement.",
+						"Changing between a secondary and a primary kind requires a replacement.",
+					),
+				},
 				Validators: []validator.String{
 					stringvalidator.OneOf("Native", "Master", "Slave", "Producer", "Consumer"),
 				},
 			},
 			"masters": schema.ListAttribute{
-				MarkdownDescription: "Masters of this zone should only be set if kind is Slave",
+				MarkdownDescription: "The IP addresses, optionally with a port, of the masters this zone is transferred from, e.g. `192.0.2.1` or `[2001:db8::1]:5300`. Required if `kind` is `Slave` or `Consumer`, must not be set otherwise.",
 				Optional:            true,
 				ElementType:         types.StringType,
-				// FIXME: Add Validator which ensures kind is set to only allowed types e.g. slave
-				Validators: []validator.List{},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(masterAddressValidator{}),
+				},
+			},
+			"catalog": schema.StringAttribute{
+				MarkdownDescription: "The catalog zone this zone is a member of, e.g. the `name` of a `pdns_zone` of kind `Producer`. Secondaries consuming the catalog provision the zone automatically. Must end with a dot and can not be set on catalog zones themselves.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`\.$`), "Catalog must end with a dot"),
+				},
+			},
+			"master_tsig_key_ids": schema.ListAttribute{
+				MarkdownDescription: "The ids of the TSIG keys secondaries must use to transfer this zone (`TSIG-ALLOW-AXFR`), e.g. the `key_id` of a `pdns_tsig_key`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators:          tsigKeyIDsValidators,
+			},
+			"slave_tsig_key_ids": schema.ListAttribute{
+				MarkdownDescription: "The ids of the TSIG keys used to transfer this zone from its masters (`AXFR-MASTER-TSIG`), e.g. the `key_id` of a `pdns_tsig_key`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators:          tsigKeyIDsValidators,
 			},
 			"soa": schema.SingleNestedAttribute{
-				MarkdownDescription: "The Start Of Authority (SOA) record parameters for the zone.",
-				Required:            true,
+				MarkdownDescription: "The Start Of Authority (SOA) record parameters for the zone. Required unless `kind` is `Slave` or `Consumer`, must not be set for those.",
+				Optional:            true,
 				Attributes: map[string]schema.Attribute{
 					"create_record": schema.BoolAttribute{
 						Description: "If set to false the provider will not create the SOA record",
@@ -233,6 +403,90 @@ func (r *ZoneResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.providerData = providerData
 }
 
+func (r *ZoneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ZoneResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Nsec3Param.IsNull() && !data.DNSSec.IsUnknown() && !data.Presigned.IsUnknown() && !data.DNSSec.ValueBool() && !data.Presigned.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("nsec3param"),
+			"Invalid Attribute Combination",
+			"NSEC3 parameters can only be set on a zone with `dnssec` or `presigned` set to `true`.",
+		)
+	}
+
+	if data.Nsec3Narrow.ValueBool() && data.Nsec3Param.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("nsec3narrow"),
+			"Invalid Attribute Combination",
+			"NSEC3 narrow mode requires `nsec3param` to be set.",
+		)
+	}
+
+	if !data.SerialStrategy.IsNull() && serialManagedByPDNS(data.SOAEditAPI) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("serial_strategy"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("The serial is managed by PowerDNS with `soa_edit_api` set to `%s`. Set `soa_edit_api` to `OFF` or leave it unset to let the provider manage the serial.", data.SOAEditAPI.ValueString()),
+		)
+	}
+
+	if data.Kind.IsUnknown() {
+		return
+	}
+
+	// An unset kind defaults to Native.
+	kind := data.Kind.ValueString()
+	if !data.Catalog.IsNull() && (kind == "Producer" || kind == "Consumer") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("catalog"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("A catalog zone of kind `%s` can not be a member of another catalog.", kind),
+		)
+	}
+	if isSecondaryKind(kind) {
+		if data.Masters.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("masters"),
+				"Missing Attribute",
+				fmt.Sprintf("A zone of kind `%s` requires `masters` to be set.", kind),
+			)
+		}
+		for attribute, value := range map[string]attr.Value{"nameservers": data.Nameservers, "soa": data.SOA, "serial_strategy": data.SerialStrategy} {
+			if !value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attribute),
+					"Invalid Attribute Combination",
+					fmt.Sprintf("A zone of kind `%s` receives `%s` from its masters, so it must not be set.", kind, attribute),
+				)
+			}
+		}
+		return
+	}
+
+	if !data.Masters.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("masters"),
+			"Invalid Attribute Combination",
+			"`masters` can only be set on a zone of kind `Slave` or `Consumer`.",
+		)
+	}
+	for attribute, value := range map[string]attr.Value{"nameservers": data.Nameservers, "soa": data.SOA} {
+		if value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Missing Attribute",
+				fmt.Sprintf("A zone of kind `%s` requires `%s` to be set.", lo.Ternary(kind == "", "Native", kind), attribute),
+			)
+		}
+	}
+}
+
 func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ZoneResourceModel
 
@@ -259,38 +513,87 @@ func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	newZone, serial, diags := createZoneFromData(ctx, data)
+	newZone, diags := createZoneFromData(ctx, data)
 	if diags.HasError() {
 		resp.Diagnostics = append(resp.Diagnostics, diags...)
 		return
 	}
 
-	data.Serial = types.StringValue(serial)
-
 	zone, err = r.providerData.pdnsClient.CreateZone(ctx, newZone)
 	if handleClientError(&resp.Diagnostics, err) {
 		return
 	}
 
+	// The serial is the one PowerDNS set through SOA-EDIT-API, or e.g. 0 for a
+	// secondary that has not been transferred yet.
+	data.Serial = types.StringValue(fmt.Sprintf("%d", zone.Serial))
+
+	if data.APIRectify.IsUnknown() {
+		data.APIRectify = types.BoolPointerValue(zone.APIRectify)
+	}
+	if data.SOAEditAPI.IsUnknown() {
+		data.SOAEditAPI = types.StringValue(zone.SOAEditAPI)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func createZoneFromData(ctx context.Context, data ZoneResourceModel) (pdns_client.PDNSZone, string, diag.Diagnostics) {
+func createZoneFromData(ctx context.Context, data ZoneResourceModel) (pdns_client.PDNSZone, diag.Diagnostics) {
 	name := data.Name.ValueString()
 	zoneDiags := make([]diag.Diagnostic, 0)
 
+	masterTSIGKeyIDs, diags := stringsFromList(ctx, data.MasterTSIGKeyIDs)
+	if diags.HasError() {
+		zoneDiags = append(zoneDiags, diags...)
+		return pdns_client.PDNSZone{}, zoneDiags
+	}
+
+	slaveTSIGKeyIDs, diags := stringsFromList(ctx, data.SlaveTSIGKeyIDs)
+	if diags.HasError() {
+		zoneDiags = append(zoneDiags, diags...)
+		return pdns_client.PDNSZone{}, zoneDiags
+	}
+
+	newZone := pdns_client.PDNSZone{
+		Name:             name,
+		Kind:             data.Kind.ValueString(),
+		Dnssec:           data.DNSSec.ValueBool(),
+		Nsec3Param:       data.Nsec3Param.ValueString(),
+		Nsec3Narrow:      data.Nsec3Narrow.ValueBool(),
+		Presigned:        data.Presigned.ValueBool(),
+		APIRectify:       lo.Ternary(data.APIRectify.IsUnknown(), nil, data.APIRectify.ValueBoolPointer()),
+		Catalog:          data.Catalog.ValueString(),
+		SOAEditAPI:       lo.Ternary(data.SOAEditAPI.IsUnknown(), "", data.SOAEditAPI.ValueString()),
+		SOAEdit:          data.SOAEdit.ValueString(),
+		MasterTsigKeyIDS: masterTSIGKeyIDs,
+		SlaveTsigKeyIDS:  slaveTSIGKeyIDs,
+	}
+
+	// Secondaries receive SOA, nameservers and all other records from their
+	// masters.
+	if isSecondaryKind(newZone.Kind) {
+		masters, diags := stringsFromList(ctx, data.Masters)
+		if diags.HasError() {
+			zoneDiags = append(zoneDiags, diags...)
+			return pdns_client.PDNSZone{}, zoneDiags
+		}
+		newZone.Masters = masters
+
+		return newZone, zoneDiags
+	}
+
 	nameservers := make([]Nameserver, 0, len(data.Nameservers.Elements()))
-	diags := data.Nameservers.ElementsAs(ctx, &nameservers, false)
+	diags = data.Nameservers.ElementsAs(ctx, &nameservers, false)
 	if diags.HasError() {
 		zoneDiags = append(zoneDiags, diags...)
-		return pdns_client.PDNSZone{}, "", zoneDiags
+		return pdns_client.PDNSZone{}, zoneDiags
 	}
 
 	var soa SOA
 	diags = data.SOA.As(ctx, &soa, basetypes.ObjectAsOptions{})
 	if diags.HasError() {
 		zoneDiags = append(zoneDiags, diags...)
-		return pdns_client.PDNSZone{}, "", zoneDiags
+		return pdns_client.PDNSZone{}, zoneDiags
 	}
 	soa.RName = fqdn(soa.RName, name)
 
@@ -303,13 +606,25 @@ func createZoneFromData(ctx context.Context, data ZoneResourceModel) (pdns_clien
 	objectValue, diags := types.ObjectValueFrom(ctx, soaModel.AttributeTypes(), soaModel)
 	if diags.HasError() {
 		zoneDiags = append(zoneDiags, diag.NewErrorDiagnostic("Parser error", "Failed to parse soa back to data model"))
-		return pdns_client.PDNSZone{}, "", zoneDiags
+		return pdns_client.PDNSZone{}, zoneDiags
 	}
 	data.SOA = objectValue
 
 	records := make([]pdns_client.Rrset, 0)
 
-	serial := time.Now().Format("20060102") + "01"
+	// PowerDNS applies SOA-EDIT-API to the SOA of a new zone, which defaults
+	// to `DEFAULT` and turns the serial into YYYYMMDD01. Without it the
+	// provider sets the first serial itself.
+	serial := "0"
+	if !data.SOAEditAPI.IsUnknown() && !serialManagedByPDNS(data.SOAEditAPI) {
+		var err error
+		serial, err = NextSOASerial(serialStrategy(data), "")
+		if err != nil {
+			zoneDiags = append(zoneDiags, diag.NewAttributeErrorDiagnostic(path.Root("serial_strategy"), "Serial Error", err.Error()))
+			return pdns_client.PDNSZone{}, zoneDiags
+		}
+	}
+
 	if soa.CreateRecord {
 		records = append(records, pdns_client.Rrset{
 			Type: "SOA",
@@ -341,7 +656,7 @@ func createZoneFromData(ctx context.Context, data ZoneResourceModel) (pdns_clien
 		parsedIP := net.ParseIP(*nameserver.Address)
 		if parsedIP == nil {
 			zoneDiags = append(zoneDiags, diag.NewAttributeErrorDiagnostic(path.Root("nameservers").AtListIndex(index).AtMapKey("address"), "Parse Error", "Invalid IPv4 or IPv6 address"))
-			return pdns_client.PDNSZone{}, "", zoneDiags
+			return pdns_client.PDNSZone{}, zoneDiags
 		}
 
 		if parsedIP.To4() != nil {
@@ -361,17 +676,12 @@ func createZoneFromData(ctx context.Context, data ZoneResourceModel) (pdns_clien
 		})
 	}
 
-	newZone := pdns_client.PDNSZone{
-		Name:   name,
-		Kind:   data.Kind.ValueString(),
-		Dnssec: false,
-		Nameservers: lo.Map(nameservers, func(item Nameserver, index int) string {
-			return item.Hostname
-		}),
-		Rrsets: records,
-	}
+	newZone.Nameservers = lo.Map(nameservers, func(item Nameserver, index int) string {
+		return item.Hostname
+	})
+	newZone.Rrsets = records
 
-	return newZone, lo.Ternary(soa.CreateRecord, serial, ""), nil
+	return newZone, nil
 }
 
 func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -385,59 +695,98 @@ func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	zone, err := r.providerData.pdnsClient.GetZone(ctx, data.Name.ValueString(), true, "")
+
+	var notFoundError *pdns_client.PDNSZoneNotFoundError
+	if errors.As(err, &notFoundError) {
+		tflog.Warn(ctx, "Zone was deleted outside of Terraform, removing it from state", map[string]any{"zone": data.Name.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if handleClientError(&resp.Diagnostics, err) {
 		return
 	}
 
 	data.DNSSec = types.BoolValue(zone.Dnssec)
+	data.Nsec3Param = lo.Ternary(zone.Nsec3Param == "", types.StringNull(), types.StringValue(zone.Nsec3Param))
+	data.Nsec3Narrow = types.BoolValue(zone.Nsec3Narrow)
+	data.Presigned = types.BoolValue(zone.Presigned)
+	data.APIRectify = types.BoolPointerValue(zone.APIRectify)
+	data.Catalog = lo.Ternary(zone.Catalog == "", types.StringNull(), types.StringValue(zone.Catalog))
+	data.SOAEditAPI = types.StringValue(zone.SOAEditAPI)
+	data.SOAEdit = lo.Ternary(zone.SOAEdit == "", types.StringNull(), types.StringValue(zone.SOAEdit))
 	data.Kind = types.StringValue(zone.Kind)
 	data.Name = types.StringValue(zone.Name)
 	data.Serial = types.StringValue(fmt.Sprintf("%d", zone.Serial))
 
-	soaRecord, isFound := lo.Find(zone.Rrsets, func(item pdns_client.Rrset) bool {
-		return item.Type == "SOA" && item.Name == data.Name.ValueString()
+	// Masters are kept in the form they were configured in if PowerDNS only
+	// normalised them, e.g. by dropping the default port.
+	priorMasters, diags := stringsFromList(ctx, data.Masters)
+	resp.Diagnostics.Append(diags...)
+	masters := lo.Map(zone.Masters, func(master string, index int) string {
+		canonical, _ := canonicalMaster(master)
+		prior, found := lo.Find(priorMasters, func(item string) bool {
+			priorCanonical, ok := canonicalMaster(item)
+			return ok && priorCanonical == canonical
+		})
+		return lo.Ternary(found, prior, master)
 	})
+	data.Masters, diags = listFromStrings(ctx, masters)
+	resp.Diagnostics.Append(diags...)
+	data.MasterTSIGKeyIDs, diags = listFromStrings(ctx, zone.MasterTsigKeyIDS)
+	resp.Diagnostics.Append(diags...)
+	data.SlaveTSIGKeyIDs, diags = listFromStrings(ctx, zone.SlaveTsigKeyIDS)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if isFound {
-		soaData := strings.Split(soaRecord.Records[0].Content, " ")
-
-		refresh, err := strconv.ParseInt(soaData[3], 10, 64)
-		if err != nil {
-			resp.Diagnostics.AddError("Parsing Error", fmt.Sprintf("Failed to parse SOA number: %s", err.Error()))
-			return
-		}
+	// The SOA and nameservers of a secondary come from its masters and are not
+	// managed by the provider.
+	if isSecondaryKind(zone.Kind) {
+		data.SOA = types.ObjectNull(SOAModel{}.AttributeTypes())
+		data.Nameservers = types.ListNull(types.ObjectType{AttrTypes: Nameserver{}.AttributeTypes()})
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 
-		retry, err := strconv.ParseInt(soaData[4], 10, 64)
-		if err != nil {
-			resp.Diagnostics.AddError("Parsing Error", fmt.Sprintf("Failed to parse SOA number: %s", err.Error()))
+	// An imported zone has no prior SOA, in which case the provider takes over
+	// the existing record.
+	currentSoaData := SOA{CreateRecord: true}
+	if !data.SOA.IsNull() && !data.SOA.IsUnknown() {
+		diags := data.SOA.As(ctx, &currentSoaData, basetypes.ObjectAsOptions{})
+		if diags.HasError() {
+			resp.Diagnostics = append(resp.Diagnostics, diags...)
 			return
 		}
+	}
 
-		expire, err := strconv.ParseInt(soaData[5], 10, 64)
-		if err != nil {
-			resp.Diagnostics.AddError("Parsing Error", fmt.Sprintf("Failed to parse SOA number: %s", err.Error()))
-			return
-		}
+	soaRecord, isFound := lo.Find(zone.Rrsets, func(item pdns_client.Rrset) bool {
+		return item.Type == "SOA" && item.Name == zone.Name && len(item.Records) > 0
+	})
 
-		ttl, err := strconv.ParseInt(soaData[6], 10, 64)
+	var soaContent SOAContent
+	if isFound {
+		soaContent, err = ParseSOAContent(soaRecord.Records[0].Content)
 		if err != nil {
-			resp.Diagnostics.AddError("Parsing Error", fmt.Sprintf("Failed to parse SOA number: %s", err.Error()))
+			resp.Diagnostics.AddError("Parsing Error", fmt.Sprintf("Failed to parse SOA record: %s", err.Error()))
 			return
 		}
+	}
 
-		var currentSoaData SOA
-		diags := data.SOA.As(ctx, &currentSoaData, basetypes.ObjectAsOptions{})
-		if diags.HasError() {
-			resp.Diagnostics = append(resp.Diagnostics, diags...)
-			return
+	// Without create_record the SOA is not managed by the provider, so the
+	// configured values are kept instead of whatever PowerDNS generated.
+	if isFound && currentSoaData.CreateRecord {
+		rname := relativeName(soaContent.RName, zone.Name)
+		if fqdn(currentSoaData.RName, zone.Name) == soaContent.RName {
+			rname = currentSoaData.RName
 		}
 
 		soa := SOAModel{
-			RName:        types.StringValue(strings.ReplaceAll(soaData[1], "."+zone.Name, "")),
-			Refresh:      types.Int64Value(refresh),
-			Retry:        types.Int64Value(retry),
-			Expire:       types.Int64Value(expire),
-			TTL:          types.Int64Value(ttl),
+			RName:        types.StringValue(rname),
+			Refresh:      types.Int64Value(soaContent.Refresh),
+			Retry:        types.Int64Value(soaContent.Retry),
+			Expire:       types.Int64Value(soaContent.Expire),
+			TTL:          types.Int64Value(soaContent.Minimum),
 			CreateRecord: types.BoolValue(currentSoaData.CreateRecord),
 		}
 
@@ -451,68 +800,94 @@ func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	currentNameservers := make([]Nameserver, 0, len(data.Nameservers.Elements()))
-	diags := data.Nameservers.ElementsAs(ctx, &currentNameservers, false)
+	diags = data.Nameservers.ElementsAs(ctx, &currentNameservers, false)
 	if diags.HasError() {
 		resp.Diagnostics = append(resp.Diagnostics, diags...)
 		return
 	}
 
-	nsDataTypes := map[string]attr.Type{
-		"address":  types.StringType,
-		"hostname": types.StringType,
+	nameservers := nameserversFromZone(zone, currentNameservers, soaContent.MName)
+
+	listValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: Nameserver{}.AttributeTypes()}, nameservers)
+	if diags.HasError() {
+		resp.Diagnostics = append(resp.Diagnostics, diags...)
+		return
 	}
 
-	elements := lo.FilterMap(currentNameservers, func(item Nameserver, index int) (attr.Value, bool) {
-		ns := fqdn(item.Hostname, zone.Name)
+	data.Nameservers = listValue
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
 
-		nsRecord, isFound := lo.Find(zone.Rrsets, func(itemRset pdns_client.Rrset) bool {
-			if len(itemRset.Records) == 0 || item.Address == nil {
-				return false
-			}
-			return (itemRset.Type == "A" || itemRset.Type == "AAAA") &&
-				itemRset.Name == ns &&
-				itemRset.Records[0].Content == *item.Address
-		})
+// nameserversFromZone rebuilds the nameservers attribute from the apex NS
+// rrset and the in-zone glue records. Entries known from the prior state keep
+// their position and the way their hostname was written; new ones are
+// appended, except for the SOA primary which goes first as the provider uses
+// the first nameserver as primary.
+func nameserversFromZone(zone pdns_client.PDNSZone, prior []Nameserver, primary string) []Nameserver {
+	nsRrset, _ := lo.Find(zone.Rrsets, func(item pdns_client.Rrset) bool {
+		return item.Type == "NS" && item.Name == zone.Name
+	})
 
-		var nsData map[string]attr.Value
+	hostnames := lo.Uniq(lo.Map(nsRrset.Records, func(item pdns_client.Record, index int) string {
+		return item.Content
+	}))
 
-		if !isFound && item.Address != nil {
-			resp.Diagnostics.AddError("Read error", fmt.Sprintf("Failed to read A or AAAA record for NS record (%s)", item.Hostname))
-			return nil, false
-		} else if !isFound && item.Address == nil {
-			nsData = map[string]attr.Value{
-				"address":  types.StringPointerValue(item.Address),
-				"hostname": types.StringValue(item.Hostname),
-			}
-		} else {
-			nsData = map[string]attr.Value{
-				"address":  types.StringValue(nsRecord.Records[0].Content),
-				"hostname": types.StringValue(strings.ReplaceAll(nsRecord.Name, "."+zone.Name, "")),
-			}
-		}
+	priorByHostname := lo.SliceToMap(prior, func(item Nameserver) (string, Nameserver) {
+		return fqdn(item.Hostname, zone.Name), item
+	})
+	priorIndex := func(hostname string) int {
+		return lo.IndexOf(lo.Map(prior, func(item Nameserver, index int) string {
+			return fqdn(item.Hostname, zone.Name)
+		}), hostname)
+	}
 
-		objValue, diags := types.ObjectValue(nsDataTypes, nsData)
-		if diags.HasError() {
-			resp.Diagnostics.Append(diags...)
-			return nil, false
+	slices.SortStableFunc(hostnames, func(a, b string) int {
+		ia, ib := priorIndex(a), priorIndex(b)
+		switch {
+		case ia >= 0 && ib >= 0:
+			return ia - ib
+		case ia >= 0:
+			return -1
+		case ib >= 0:
+			return 1
+		case len(prior) == 0 && a == primary:
+			return -1
+		case len(prior) == 0 && b == primary:
+			return 1
+		default:
+			return 0
 		}
-
-		return objValue, true
 	})
 
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	return lo.Map(hostnames, func(hostname string, index int) Nameserver {
+		nameserver := Nameserver{Hostname: relativeName(hostname, zone.Name)}
 
-	listValue, diags := types.ListValue(types.ObjectType{AttrTypes: nsDataTypes}, elements)
-	if diags.HasError() {
-		resp.Diagnostics = append(resp.Diagnostics, diags...)
-		return
-	}
+		priorNameserver, hasPrior := priorByHostname[hostname]
+		if hasPrior {
+			nameserver.Hostname = priorNameserver.Hostname
+		}
 
-	data.Nameservers = listValue
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		glue := lo.FlatMap(
+			lo.Filter(zone.Rrsets, func(item pdns_client.Rrset, index int) bool {
+				return item.Name == hostname && (item.Type == "A" || item.Type == "AAAA")
+			}),
+			func(item pdns_client.Rrset, index int) []string {
+				return lo.Map(item.Records, func(record pdns_client.Record, index int) string {
+					return record.Content
+				})
+			},
+		)
+
+		switch {
+		case hasPrior && priorNameserver.Address != nil && lo.Contains(glue, *priorNameserver.Address):
+			nameserver.Address = priorNameserver.Address
+		case len(glue) > 0:
+			nameserver.Address = &glue[0]
+		}
+
+		return nameserver
+	})
 }
 
 func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -526,13 +901,34 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	// SOA-EDIT-API decides whether PowerDNS or the provider increases the
+	// serial for the record changes below, so it has to be changed first.
+	soaEditAPI := state.SOAEditAPI
+	if !state.SOAEditAPI.Equal(plan.SOAEditAPI) && !plan.SOAEditAPI.IsUnknown() {
+		err := r.providerData.pdnsClient.UpdateZone(ctx, plan.Name.ValueString(), pdns_client.PDNSZoneUpdate{
+			SOAEditAPI: plan.SOAEditAPI.ValueStringPointer(),
+		})
+		if handleClientError(&resp.Diagnostics, err) {
+			return
+		}
+		soaEditAPI = plan.SOAEditAPI
+	}
+
 	if !state.Nameservers.Equal(plan.Nameservers) || !state.SOA.Equal(plan.SOA) {
 		records := make([]pdns_client.Rrset, 0)
 
-		serial, err := IncreaseSOASerial(state.Serial.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Client error", fmt.Sprintf("Failed to increase SOA serial: %s", err.Error()))
-			return
+		// A zone with SOA-EDIT-API gets its serial increased by PowerDNS, so the
+		// SOA keeps the current one. Otherwise the provider has to do it and
+		// always writes the SOA, as nothing else would change the serial.
+		serialManaged := serialManagedByPDNS(soaEditAPI)
+		serial := state.Serial.ValueString()
+		if !serialManaged {
+			var err error
+			serial, err = NextSOASerial(serialStrategy(plan), serial)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("serial_strategy"), "Serial Error", fmt.Sprintf("Failed to increase SOA serial: %s", err.Error()))
+				return
+			}
 		}
 
 		currentNameservers := make([]Nameserver, 0, len(state.Nameservers.Elements()))
@@ -550,8 +946,6 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 		addedOrChanged, deleted := Diff(newNameservers, currentNameservers)
 
-		tflog.Info(ctx, "DBG", map[string]any{"added": addedOrChanged, "del": deleted})
-
 		for i, nameserver := range addedOrChanged {
 			var recordType string
 			if nameserver.Address == nil {
@@ -625,7 +1019,7 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			}),
 		})
 
-		if !state.SOA.Equal(plan.SOA) || (len(plan.Nameservers.Elements()) >= 1 && len(state.Nameservers.Elements()) == 0) || !state.Nameservers.Elements()[0].Equal(plan.Nameservers.Elements()[0]) {
+		if !serialManaged || !state.SOA.Equal(plan.SOA) || (len(plan.Nameservers.Elements()) >= 1 && len(state.Nameservers.Elements()) == 0) || !state.Nameservers.Elements()[0].Equal(plan.Nameservers.Elements()[0]) {
 
 			var newSoaData SOA
 			diags = plan.SOA.As(ctx, &newSoaData, basetypes.ObjectAsOptions{})
@@ -662,26 +1056,89 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			"records": records,
 		})
 
-		err = r.providerData.pdnsClient.UpdateZoneRecords(ctx, plan.Name.ValueString(), records)
+		err := r.providerData.pdnsClient.UpdateZoneRecords(ctx, plan.Name.ValueString(), records)
 		if handleClientError(&resp.Diagnostics, err) {
 			return
 		}
 
+		if serialManaged {
+			zone, err := r.providerData.pdnsClient.GetZone(ctx, plan.Name.ValueString(), false, "")
+			if handleClientError(&resp.Diagnostics, err) {
+				return
+			}
+			serial = fmt.Sprintf("%d", zone.Serial)
+		}
 		plan.Serial = types.StringValue(serial)
 	}
 
-	if !state.DNSSec.Equal(plan.DNSSec) || !state.Kind.Equal(plan.Kind) {
-		zoneUpdate := pdns_client.PDNSZone{
-			Dnssec: plan.DNSSec.ValueBool(),
-			Kind:   plan.Kind.ValueString(),
-		}
+	zoneUpdate := pdns_client.PDNSZoneUpdate{}
+	if !state.Kind.Equal(plan.Kind) {
+		zoneUpdate.Kind = plan.Kind.ValueString()
+	}
+	if !state.DNSSec.Equal(plan.DNSSec) {
+		zoneUpdate.Dnssec = plan.DNSSec.ValueBoolPointer()
+	}
+	if !state.Nsec3Param.Equal(plan.Nsec3Param) {
+		// An empty value switches the zone back to NSEC.
+		nsec3Param := plan.Nsec3Param.ValueString()
+		zoneUpdate.Nsec3Param = &nsec3Param
+	}
+	if !state.Nsec3Narrow.Equal(plan.Nsec3Narrow) {
+		zoneUpdate.Nsec3Narrow = plan.Nsec3Narrow.ValueBoolPointer()
+	}
+	if !state.Presigned.Equal(plan.Presigned) {
+		zoneUpdate.Presigned = plan.Presigned.ValueBoolPointer()
+	}
+	if !state.APIRectify.Equal(plan.APIRectify) && !plan.APIRectify.IsUnknown() {
+		zoneUpdate.APIRectify = plan.APIRectify.ValueBoolPointer()
+	}
+	if !state.Catalog.Equal(plan.Catalog) {
+		// An empty catalog removes the zone from its catalog.
+		catalog := plan.Catalog.ValueString()
+		zoneUpdate.Catalog = &catalog
+	}
+	if !state.SOAEdit.Equal(plan.SOAEdit) {
+		// An empty value turns SOA-EDIT off.
+		soaEdit := plan.SOAEdit.ValueString()
+		zoneUpdate.SOAEdit = &soaEdit
+	}
+	if !state.Masters.Equal(plan.Masters) {
+		masters, diags := stringsFromList(ctx, plan.Masters)
+		resp.Diagnostics.Append(diags...)
+		zoneUpdate.Masters = &masters
+	}
+	if !state.MasterTSIGKeyIDs.Equal(plan.MasterTSIGKeyIDs) {
+		masterTSIGKeyIDs, diags := stringsFromList(ctx, plan.MasterTSIGKeyIDs)
+		resp.Diagnostics.Append(diags...)
+		zoneUpdate.MasterTsigKeyIDS = &masterTSIGKeyIDs
+	}
+	if !state.SlaveTSIGKeyIDs.Equal(plan.SlaveTSIGKeyIDs) {
+		slaveTSIGKeyIDs, diags := stringsFromList(ctx, plan.SlaveTSIGKeyIDs)
+		resp.Diagnostics.Append(diags...)
+		zoneUpdate.SlaveTsigKeyIDS = &slaveTSIGKeyIDs
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	if zoneUpdate != (pdns_client.PDNSZoneUpdate{}) {
 		err := r.providerData.pdnsClient.UpdateZone(ctx, plan.Name.ValueString(), zoneUpdate)
 		if handleClientError(&resp.Diagnostics, err) {
 			return
 		}
 	}
 
+	// Only record changes touch the SOA, so settings-only updates keep the serial.
+	if plan.Serial.IsUnknown() {
+		plan.Serial = state.Serial
+	}
+	if plan.APIRectify.IsUnknown() {
+		plan.APIRectify = state.APIRectify
+	}
+	if plan.SOAEditAPI.IsUnknown() {
+		plan.SOAEditAPI = state.SOAEditAPI
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -699,7 +1156,9 @@ func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	handleClientError(&resp.Diagnostics, err)
 }
 
-// TODO: Import zone by name
+// ImportState imports a zone by its name, e.g. `example.com.`. Read then
+// reconstructs nameservers, SOA, masters, kind, catalog, the SOA-EDIT settings,
+// the DNSSEC settings and the TSIG keys from the API.
 func (r *ZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
@@ -0,0 +1,403 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+func TestNameserversFromZone(t *testing.T) {
+	address := func(address string) *string { return &address }
+	rrset := func(name, rrType string, contents ...string) pdns_client.Rrset {
+		rrset := pdns_client.Rrset{Name: name, Type: rrType}
+		for _, content := range contents {
+			rrset.Records = append(rrset.Records, pdns_client.Record{Content: content})
+		}
+		return rrset
+	}
+
+	zone := pdns_client.PDNSZone{
+		Name: "example.com.",
+		Rrsets: []pdns_client.Rrset{
+			rrset("example.com.", "NS", "ns1.example.com.", "ns2.example.com.", "ns.example.net."),
+			rrset("ns1.example.com.", "A", "10.10.10.1"),
+			rrset("ns2.example.com.", "A", "10.10.10.2", "10.10.10.3"),
+			rrset("ns2.example.com.", "AAAA", "2001:db8::2"),
+		},
+	}
+
+	tests := map[string]struct {
+		prior   []Nameserver
+		primary string
+		want    []Nameserver
+	}{
+		"import puts the SOA primary first": {
+			primary: "ns2.example.com.",
+			want: []Nameserver{
+				{Hostname: "ns2", Address: address("10.10.10.2")},
+				{Hostname: "ns1", Address: address("10.10.10.1")},
+				{Hostname: "ns.example.net."},
+			},
+		},
+		"import with an out of zone primary": {
+			primary: "ns.example.net.",
+			want: []Nameserver{
+				{Hostname: "ns.example.net."},
+				{Hostname: "ns1", Address: address("10.10.10.1")},
+				{Hostname: "ns2", Address: address("10.10.10.2")},
+			},
+		},
+		"prior order and hostnames are kept": {
+			prior: []Nameserver{
+				{Hostname: "ns.example.net."},
+				{Hostname: "ns2.example.com.", Address: address("10.10.10.2")},
+				{Hostname: "ns1", Address: address("10.10.10.1")},
+			},
+			primary: "ns1.example.com.",
+			want: []Nameserver{
+				{Hostname: "ns.example.net."},
+				{Hostname: "ns2.example.com.", Address: address("10.10.10.2")},
+				{Hostname: "ns1", Address: address("10.10.10.1")},
+			},
+		},
+		"prior address among the glue is kept": {
+			prior: []Nameserver{
+				{Hostname: "ns1", Address: address("10.10.10.1")},
+				{Hostname: "ns2", Address: address("2001:db8::2")},
+				{Hostname: "ns.example.net."},
+			},
+			primary: "ns1.example.com.",
+			want: []Nameserver{
+				{Hostname: "ns1", Address: address("10.10.10.1")},
+				{Hostname: "ns2", Address: address("2001:db8::2")},
+				{Hostname: "ns.example.net."},
+			},
+		},
+		"prior address without glue is replaced": {
+			prior: []Nameserver{
+				{Hostname: "ns1", Address: address("10.10.10.9")},
+			},
+			primary: "ns1.example.com.",
+			want: []Nameserver{
+				{Hostname: "ns1", Address: address("10.10.10.1")},
+				{Hostname: "ns2", Address: address("10.10.10.2")},
+				{Hostname: "ns.example.net."},
+			},
+		},
+		"nameservers added outside of Terraform are appended": {
+			prior: []Nameserver{
+				{Hostname: "ns2", Address: address("10.10.10.3")},
+			},
+			primary: "ns1.example.com.",
+			want: []Nameserver{
+				{Hostname: "ns2", Address: address("10.10.10.3")},
+				{Hostname: "ns1", Address: address("10.10.10.1")},
+				{Hostname: "ns.example.net."},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := nameserversFromZone(zone, test.prior, test.primary)
+			if len(got) != len(test.want) {
+				t.Fatalf("nameservers = %s, want %s", formatNameservers(got), formatNameservers(test.want))
+			}
+			for i := range got {
+				if got[i].Hostname != test.want[i].Hostname || stringValue(got[i].Address) != stringValue(test.want[i].Address) {
+					t.Errorf("nameservers = %s, want %s", formatNameservers(got), formatNameservers(test.want))
+					break
+				}
+			}
+		})
+	}
+}
+
+func formatNameservers(nameservers []Nameserver) string {
+	formatted := ""
+	for _, nameserver := range nameservers {
+		formatted += " " + nameserver.Hostname + "=" + stringValue(nameserver.Address)
+	}
+	return "[" + formatted + " ]"
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return "<nil>"
+	}
+	return *value
+}
+
+// zoneConfig returns a pdns_zone configuration for example.com. with
+// nameservers and soa, overridden by attributes given as plain Go values. A
+// nil value unsets the attribute.
+func zoneConfig(t *testing.T, attributes map[string]any) tfsdk.Config {
+	t.Helper()
+
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&ZoneResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	config := map[string]any{
+		"name":        "example.com.",
+		"nameservers": []any{map[string]any{"hostname": "ns1", "address": "10.10.10.1"}},
+		"soa":         map[string]any{"rname": "hostmaster"},
+	}
+	maps.Copy(config, attributes)
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = terraformValue(t, attributeType, config[name])
+	}
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+}
+
+// terraformValue converts value to a value of typ. Attributes missing from
+// objects are null, tftypes.Values are taken as they are.
+func terraformValue(t *testing.T, typ tftypes.Type, value any) tftypes.Value {
+	t.Helper()
+
+	switch value := value.(type) {
+	case nil:
+		return tftypes.NewValue(typ, nil)
+	case tftypes.Value:
+		return value
+	case int:
+		return tftypes.NewValue(typ, big.NewFloat(float64(value)))
+	case []any:
+		elements := make([]tftypes.Value, len(value))
+		for i, element := range value {
+			elements[i] = terraformValue(t, typ.(tftypes.List).ElementType, element)
+		}
+		return tftypes.NewValue(typ, elements)
+	case map[string]any:
+		attributes := make(map[string]tftypes.Value)
+		for name, attributeType := range typ.(tftypes.Object).AttributeTypes {
+			attributes[name] = terraformValue(t, attributeType, value[name])
+		}
+		return tftypes.NewValue(typ, attributes)
+	default:
+		return tftypes.NewValue(typ, value)
+	}
+}
+
+// validateZoneConfig runs the config validation of pdns_zone and returns the
+// attribute paths of the errors.
+func validateZoneConfig(t *testing.T, config tfsdk.Config) []path.Path {
+	t.Helper()
+
+	var resp resource.ValidateConfigResponse
+	(&ZoneResource{}).ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, &resp)
+
+	var paths []path.Path
+	for _, diagnostic := range resp.Diagnostics.Errors() {
+		withPath, ok := diagnostic.(diag.DiagnosticWithPath)
+		if !ok {
+			t.Fatalf("diagnostic without attribute path: %s: %s", diagnostic.Summary(), diagnostic.Detail())
+		}
+		paths = append(paths, withPath.Path())
+	}
+	return paths
+}
+
+func TestNSEC3ParamRegex(t *testing.T) {
+	tests := map[string]bool{
+		"1 0 0 -":         true,
+		"1 1 65535 ab":    true,
+		"1 0 10 0123abCD": true,
+		"1 0 65536 -":     false,
+		"1 0 010 -":       false,
+		"2 0 0 -":         false,
+		"1 2 0 -":         false,
+		"1 0 0 abc":       false,
+		"1 0 0":           false,
+		"1 0 0 - ":        false,
+		"1  0 0 -":        false,
+	}
+
+	for nsec3param, valid := range tests {
+		if got := NSEC3PARAM_REGEX.MatchString(nsec3param); got != valid {
+			t.Errorf("NSEC3PARAM_REGEX matches %q = %t, want %t", nsec3param, got, valid)
+		}
+	}
+}
+
+func TestZoneResourceValidateConfig_dnssec(t *testing.T) {
+	tests := map[string]struct {
+		attributes map[string]any
+		wantPaths  []path.Path
+	}{
+		"nsec3param with dnssec": {
+			attributes: map[string]any{"dnssec": true, "nsec3param": "1 0 0 -"},
+		},
+		"nsec3param with presigned": {
+			attributes: map[string]any{"presigned": true, "nsec3param": "1 0 0 -"},
+		},
+		"nsec3param without dnssec": {
+			attributes: map[string]any{"nsec3param": "1 0 0 -"},
+			wantPaths:  []path.Path{path.Root("nsec3param")},
+		},
+		"nsec3param with dnssec false": {
+			attributes: map[string]any{"dnssec": false, "nsec3param": "1 0 0 -"},
+			wantPaths:  []path.Path{path.Root("nsec3param")},
+		},
+		"nsec3param with unknown dnssec": {
+			attributes: map[string]any{"dnssec": tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue), "nsec3param": "1 0 0 -"},
+		},
+		"nsec3narrow with nsec3param": {
+			attributes: map[string]any{"dnssec": true, "nsec3param": "1 0 0 -", "nsec3narrow": true},
+		},
+		"nsec3narrow without nsec3param": {
+			attributes: map[string]any{"dnssec": true, "nsec3narrow": true},
+			wantPaths:  []path.Path{path.Root("nsec3narrow")},
+		},
+		"nsec3narrow false without nsec3param": {
+			attributes: map[string]any{"dnssec": true, "nsec3narrow": false},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			paths := validateZoneConfig(t, zoneConfig(t, test.attributes))
+			if !slices.EqualFunc(paths, test.wantPaths, path.Path.Equal) {
+				t.Errorf("error paths = %v, want %v", paths, test.wantPaths)
+			}
+		})
+	}
+}
+
+func TestZoneResourceValidateConfig_secondary(t *testing.T) {
+	secondary := func(kind string, attributes map[string]any) map[string]any {
+		config := map[string]any{"kind": kind, "masters": []any{"192.0.2.1"}, "nameservers": nil, "soa": nil}
+		maps.Copy(config, attributes)
+		return config
+	}
+
+	tests := map[string]struct {
+		attributes map[string]any
+		wantPaths  []path.Path
+	}{
+		"slave with masters": {
+			attributes: secondary("Slave", nil),
+		},
+		"consumer with masters": {
+			attributes: secondary("Consumer", nil),
+		},
+		"slave without masters": {
+			attributes: secondary("Slave", map[string]any{"masters": nil}),
+			wantPaths:  []path.Path{path.Root("masters")},
+		},
+		"consumer without masters": {
+			attributes: secondary("Consumer", map[string]any{"masters": nil}),
+			wantPaths:  []path.Path{path.Root("masters")},
+		},
+		"slave with nameservers": {
+			attributes: secondary("Slave", map[string]any{"nameservers": []any{map[string]any{"hostname": "ns1", "address": "10.10.10.1"}}}),
+			wantPaths:  []path.Path{path.Root("nameservers")},
+		},
+		"slave with soa": {
+			attributes: secondary("Slave", map[string]any{"soa": map[string]any{"rname": "hostmaster"}}),
+			wantPaths:  []path.Path{path.Root("soa")},
+		},
+		"consumer with serial_strategy": {
+			attributes: secondary("Consumer", map[string]any{"serial_strategy": "epoch"}),
+			wantPaths:  []path.Path{path.Root("serial_strategy")},
+		},
+		"slave with unknown masters": {
+			attributes: secondary("Slave", map[string]any{"masters": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, tftypes.UnknownValue)}),
+		},
+		"native with masters": {
+			attributes: map[string]any{"masters": []any{"192.0.2.1"}},
+			wantPaths:  []path.Path{path.Root("masters")},
+		},
+		"unset kind with masters": {
+			attributes: map[string]any{"kind": nil, "masters": []any{"192.0.2.1"}},
+			wantPaths:  []path.Path{path.Root("masters")},
+		},
+		"master with masters": {
+			attributes: map[string]any{"kind": "Master", "masters": []any{"192.0.2.1"}},
+			wantPaths:  []path.Path{path.Root("masters")},
+		},
+		"producer with masters": {
+			attributes: map[string]any{"kind": "Producer", "masters": []any{"192.0.2.1"}},
+			wantPaths:  []path.Path{path.Root("masters")},
+		},
+		"native without nameservers and soa": {
+			attributes: map[string]any{"kind": "Native", "nameservers": nil, "soa": nil},
+			wantPaths:  []path.Path{path.Root("nameservers"), path.Root("soa")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			paths := validateZoneConfig(t, zoneConfig(t, test.attributes))
+			// The errors of the secondary branch are reported in map order.
+			slices.SortFunc(paths, func(a, b path.Path) int { return strings.Compare(a.String(), b.String()) })
+			if !slices.EqualFunc(paths, test.wantPaths, path.Path.Equal) {
+				t.Errorf("error paths = %v, want %v", paths, test.wantPaths)
+			}
+		})
+	}
+}
+
+func TestZoneResourceKindRequiresReplace(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&ZoneResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	planModifier := schemaResp.Schema.Attributes["kind"].(schema.StringAttribute).PlanModifiers[0]
+
+	tests := map[string]struct {
+		state string
+		plan  string
+		want  bool
+	}{
+		"native to master":   {state: "Native", plan: "Master", want: false},
+		"master to producer": {state: "Master", plan: "Producer", want: false},
+		"native to slave":    {state: "Native", plan: "Slave", want: true},
+		"master to consumer": {state: "Master", plan: "Consumer", want: true},
+		"slave to native":    {state: "Slave", plan: "Native", want: true},
+		"consumer to master": {state: "Consumer", plan: "Master", want: true},
+		"slave to consumer":  {state: "Slave", plan: "Consumer", want: false},
+		"unchanged slave":    {state: "Slave", plan: "Slave", want: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := zoneConfig(t, map[string]any{"kind": test.state})
+			plan := zoneConfig(t, map[string]any{"kind": test.plan})
+			req := planmodifier.StringRequest{
+				Path:        path.Root("kind"),
+				ConfigValue: types.StringValue(test.plan),
+				PlanValue:   types.StringValue(test.plan),
+				StateValue:  types.StringValue(test.state),
+				Config:      plan,
+				Plan:        tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				State:       tfsdk.State{Schema: state.Schema, Raw: state.Raw},
+			}
+			resp := planmodifier.StringResponse{PlanValue: req.PlanValue}
+			planModifier.PlanModifyString(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if resp.RequiresReplace != test.want {
+				t.Errorf("%s to %s requires replace = %t, want %t", test.state, test.plan, resp.RequiresReplace, test.want)
+			}
+		})
+	}
+}
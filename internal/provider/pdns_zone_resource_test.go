@@ -0,0 +1,345 @@
+package provider_test
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/acctest"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+func testZoneConfig(refresh int) map[string]any {
+	return map[string]any{
+		"name": "example.com.",
+		"nameservers": []any{
+			map[string]any{"hostname": "ns1", "address": "10.10.10.1"},
+			map[string]any{"hostname": "ns2", "address": "10.10.10.2"},
+		},
+		"soa": map[string]any{
+			"rname":   "hostmaster",
+			"refresh": refresh,
+			"retry":   3600,
+			"expire":  604800,
+			"ttl":     3600,
+		},
+	}
+}
+
+func TestAccZoneResource(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	state, err := d.Create("pdns_zone", testZoneConfig(10800))
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	zone, ok := d.Server.Zone("example.com.")
+	if !ok {
+		t.Fatal("zone was not created")
+	}
+	if zone.Kind != "Native" {
+		t.Errorf("kind = %q, want Native", zone.Kind)
+	}
+	if rrset, ok := d.Server.Rrset("example.com.", "example.com.", "NS"); !ok || len(rrset.Records) != 2 {
+		t.Errorf("NS rrset = %+v, want two nameservers", rrset)
+	}
+	if rrset, ok := d.Server.Rrset("example.com.", "ns2.example.com.", "A"); !ok || rrset.Records[0].Content != "10.10.10.2" {
+		t.Errorf("glue rrset = %+v, want 10.10.10.2", rrset)
+	}
+
+	read, err := d.Read("pdns_zone", state)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if !read.Equal(state) {
+		t.Errorf("read drifted from state:\n got: %s\nwant: %s", read, state)
+	}
+
+	imported, err := d.Import("pdns_zone", "example.com.")
+	if err != nil {
+		t.Fatalf("import: %s", err)
+	}
+	planned, err := d.Plan("pdns_zone", imported, testZoneConfig(10800))
+	if err != nil {
+		t.Fatalf("plan after import: %s", err)
+	}
+	if !planned.Equal(imported) {
+		t.Errorf("plan after import is not empty:\n got: %s\nwant: %s", planned, imported)
+	}
+
+	state, err = d.Update("pdns_zone", state, testZoneConfig(7200))
+	if err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	soa, _ := d.Server.Rrset("example.com.", "example.com.", "SOA")
+	if fields := strings.Fields(soa.Records[0].Content); fields[3] != "7200" {
+		t.Errorf("SOA = %q, want refresh 7200", soa.Records[0].Content)
+	}
+	if serial := acctest.StringAttribute(state, "serial"); !strings.Contains(soa.Records[0].Content, " "+serial+" ") {
+		t.Errorf("serial in state %q does not match SOA %q", serial, soa.Records[0].Content)
+	}
+
+	if err := d.Destroy("pdns_zone", state); err != nil {
+		t.Fatalf("destroy: %s", err)
+	}
+	if _, ok := d.Server.Zone("example.com."); ok {
+		t.Error("zone still exists after destroy")
+	}
+}
+
+func TestAccZoneResource_removedOutOfBand(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	state, err := d.Create("pdns_zone", testZoneConfig(10800))
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	d.Server.RemoveZone("example.com.")
+
+	read, err := d.Read("pdns_zone", state)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if !read.IsNull() {
+		t.Errorf("zone was not removed from state: %s", read)
+	}
+}
+
+func TestAccZoneResource_serialStrategyRemoved(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	config := testZoneConfig(10800)
+	config["serial_strategy"] = "date"
+
+	state, err := d.Create("pdns_zone", config)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	if got := acctest.StringAttribute(state, "soa_edit_api"); got != "OFF" {
+		t.Errorf("soa_edit_api with serial_strategy = %q, want OFF", got)
+	}
+
+	// Without serial_strategy the serial is handed back to PowerDNS instead of
+	// staying frozen with the OFF the provider set.
+	state, err = d.Update("pdns_zone", state, testZoneConfig(10800))
+	if err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	if got := acctest.StringAttribute(state, "soa_edit_api"); got != "DEFAULT" {
+		t.Errorf("soa_edit_api after removing serial_strategy = %q, want DEFAULT", got)
+	}
+	if zone, _ := d.Server.Zone("example.com."); zone.SOAEditAPI != "DEFAULT" {
+		t.Errorf("SOA-EDIT-API on the server = %q, want DEFAULT", zone.SOAEditAPI)
+	}
+
+	planned, err := d.Plan("pdns_zone", state, testZoneConfig(10800))
+	if err != nil {
+		t.Fatalf("plan: %s", err)
+	}
+	if !planned.Equal(state) {
+		t.Errorf("plan after update is not empty:\n got: %s\nwant: %s", planned, state)
+	}
+
+	// An explicitly configured OFF is kept.
+	config = testZoneConfig(10800)
+	config["soa_edit_api"] = "OFF"
+	if state, err = d.Update("pdns_zone", state, config); err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	planned, err = d.Plan("pdns_zone", state, config)
+	if err != nil {
+		t.Fatalf("plan: %s", err)
+	}
+	if got := acctest.StringAttribute(planned, "soa_edit_api"); got != "OFF" {
+		t.Errorf("planned soa_edit_api = %q, want OFF", got)
+	}
+}
+
+func TestAccZoneResource_keepSerial(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	config := testZoneConfig(10800)
+	config["serial_strategy"] = "keep"
+
+	today := time.Now().Format("20060102") + "01"
+	state, err := d.Create("pdns_zone", config)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	if got := acctest.StringAttribute(state, "serial"); got != today {
+		t.Errorf("serial of a new zone = %q, want %q", got, today)
+	}
+
+	config = testZoneConfig(7200)
+	config["serial_strategy"] = "keep"
+	if state, err = d.Update("pdns_zone", state, config); err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	if got := acctest.StringAttribute(state, "serial"); got != today {
+		t.Errorf("serial after update = %q, want it kept at %q", got, today)
+	}
+}
+
+func TestAccZoneResource_switchSerialOwner(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	config := testZoneConfig(10800)
+	config["soa_edit_api"] = "INCREASE"
+
+	state, err := d.Create("pdns_zone", config)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	serial := acctest.StringAttribute(state, "serial")
+
+	// Handing the serial to the provider and changing the SOA in one apply
+	// already follows the new strategy, without PowerDNS increasing it.
+	config = testZoneConfig(7200)
+	config["soa_edit_api"] = "OFF"
+	config["serial_strategy"] = "keep"
+	if state, err = d.Update("pdns_zone", state, config); err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	if got := acctest.StringAttribute(state, "serial"); got != serial {
+		t.Errorf("serial after switching to keep = %q, want it kept at %q", got, serial)
+	}
+	if zone, _ := d.Server.Zone("example.com."); strconv.FormatInt(zone.Serial, 10) != serial {
+		t.Errorf("serial on the server = %d, want %s", zone.Serial, serial)
+	}
+
+	// Handing it back to PowerDNS in one apply leaves the increase to
+	// PowerDNS with the new SOA-EDIT-API.
+	start := time.Now().Unix()
+	config = testZoneConfig(3600)
+	config["soa_edit_api"] = "EPOCH"
+	if state, err = d.Update("pdns_zone", state, config); err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	zone, _ := d.Server.Zone("example.com.")
+	if zone.Serial < start || zone.Serial > time.Now().Unix() {
+		t.Errorf("serial on the server = %d, want an EPOCH serial", zone.Serial)
+	}
+	if got := acctest.StringAttribute(state, "serial"); got != strconv.FormatInt(zone.Serial, 10) {
+		t.Errorf("serial in state = %q, want %d", got, zone.Serial)
+	}
+}
+
+func TestAccZoneResource_importNameservers(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	// The first nameserver is the SOA primary, which import has to put first
+	// again although it does not come first in the NS rrset.
+	config := testZoneConfig(10800)
+	config["nameservers"] = []any{
+		map[string]any{"hostname": "ns2", "address": "10.10.10.2"},
+		map[string]any{"hostname": "ns1", "address": "10.10.10.1"},
+	}
+	if _, err := d.Create("pdns_zone", config); err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	// PowerDNS returns the records of an rrset ordered by content.
+	zone, _ := d.Server.Zone("example.com.")
+	for _, rrset := range zone.Rrsets {
+		slices.SortFunc(rrset.Records, func(a, b pdns_client.Record) int { return strings.Compare(a.Content, b.Content) })
+	}
+	d.Server.SetZone(zone)
+
+	imported, err := d.Import("pdns_zone", "example.com.")
+	if err != nil {
+		t.Fatalf("import: %s", err)
+	}
+	planned, err := d.Plan("pdns_zone", imported, config)
+	if err != nil {
+		t.Fatalf("plan after import: %s", err)
+	}
+	if !planned.Equal(imported) {
+		t.Errorf("plan after import is not empty:\n got: %s\nwant: %s", planned, imported)
+	}
+}
+
+func TestAccZoneResource_nsec3(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	config := testZoneConfig(10800)
+	config["dnssec"] = true
+	config["nsec3param"] = "1 0 0 -"
+	config["nsec3narrow"] = true
+
+	state, err := d.Create("pdns_zone", config)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	if zone, _ := d.Server.Zone("example.com."); zone.Nsec3Param != "1 0 0 -" || !zone.Nsec3Narrow {
+		t.Errorf("NSEC3 settings = %q narrow %t, want 1 0 0 - narrow", zone.Nsec3Param, zone.Nsec3Narrow)
+	}
+
+	// Removing nsec3param switches the zone back to NSEC.
+	config = testZoneConfig(10800)
+	config["dnssec"] = true
+	if state, err = d.Update("pdns_zone", state, config); err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	if zone, _ := d.Server.Zone("example.com."); zone.Nsec3Param != "" || zone.Nsec3Narrow {
+		t.Errorf("NSEC3 settings = %q narrow %t, want NSEC", zone.Nsec3Param, zone.Nsec3Narrow)
+	}
+
+	read, err := d.Read("pdns_zone", state)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if !read.Equal(state) {
+		t.Errorf("read drifted from state:\n got: %s\nwant: %s", read, state)
+	}
+}
+
+func TestAccZoneResource_catalog(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+	d.Server.SetZone(pdns_client.PDNSZone{Name: "catalog-a.example.", Kind: "Producer"})
+	d.Server.SetZone(pdns_client.PDNSZone{Name: "catalog-b.example.", Kind: "Producer"})
+
+	config := testZoneConfig(10800)
+	config["catalog"] = "catalog-a.example."
+	state, err := d.Create("pdns_zone", config)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	if zone, _ := d.Server.Zone("example.com."); zone.Catalog != "catalog-a.example." {
+		t.Errorf("catalog on the server = %q, want %q", zone.Catalog, "catalog-a.example.")
+	}
+
+	config["catalog"] = "catalog-b.example."
+	if state, err = d.Update("pdns_zone", state, config); err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	if zone, _ := d.Server.Zone("example.com."); zone.Catalog != "catalog-b.example." {
+		t.Errorf("catalog on the server after the change = %q, want %q", zone.Catalog, "catalog-b.example.")
+	}
+	if got := acctest.StringAttribute(state, "catalog"); got != "catalog-b.example." {
+		t.Errorf("catalog = %q, want %q", got, "catalog-b.example.")
+	}
+
+	// Removing the catalog from the configuration takes the zone out of it.
+	delete(config, "catalog")
+	if state, err = d.Update("pdns_zone", state, config); err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	if zone, _ := d.Server.Zone("example.com."); zone.Catalog != "" {
+		t.Errorf("catalog on the server after clearing = %q, want none", zone.Catalog)
+	}
+	if !acctest.Attribute(state, "catalog").IsNull() {
+		t.Errorf("catalog = %s, want null", acctest.Attribute(state, "catalog"))
+	}
+
+	read, err := d.Read("pdns_zone", state)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if !read.Equal(state) {
+		t.Errorf("read drifted from state:\n got: %s\nwant: %s", read, state)
+	}
+}
@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/samber/lo"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+var (
+	_ datasource.DataSource              = &ZonesDataSource{}
+	_ datasource.DataSourceWithConfigure = &ZonesDataSource{}
+)
+
+func NewZonesDataSource() datasource.DataSource {
+	return &ZonesDataSource{}
+}
+
+type ZonesDataSource struct {
+	providerData *PDNSProviderData
+}
+
+type ZonesDataSourceModel struct {
+	Name          types.String `tfsdk:"name"`
+	NameRegex     types.String `tfsdk:"name_regex"`
+	Kind          types.String `tfsdk:"kind"`
+	Account       types.String `tfsdk:"account"`
+	Catalog       types.String `tfsdk:"catalog"`
+	IncludeDNSSec types.Bool   `tfsdk:"include_dnssec"`
+	Names         types.List   `tfsdk:"names"`
+	Zones         types.List   `tfsdk:"zones"`
+}
+
+type ZoneSummaryModel struct {
+	Name           types.String `tfsdk:"name"`
+	Kind           types.String `tfsdk:"kind"`
+	Serial         types.Int64  `tfsdk:"serial"`
+	NotifiedSerial types.Int64  `tfsdk:"notified_serial"`
+	DNSSec         types.Bool   `tfsdk:"dnssec"`
+	Account        types.String `tfsdk:"account"`
+	Catalog        types.String `tfsdk:"catalog"`
+	Masters        types.List   `tfsdk:"masters"`
+}
+
+func (m ZoneSummaryModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":            types.StringType,
+		"kind":            types.StringType,
+		"serial":          types.Int64Type,
+		"notified_serial": types.Int64Type,
+		"dnssec":          types.BoolType,
+		"account":         types.StringType,
+		"catalog":         types.StringType,
+		"masters":         types.ListType{ElemType: types.StringType},
+	}
+}
+
+func (d *ZonesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zones"
+}
+
+func (d *ZonesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the zones of a PowerDNS server, optionally filtered by name, kind, account or catalog.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only return the zone with exactly this name. The filter is applied by PowerDNS. Must end with a dot",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`\.$`), "Name must end with a dot"),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return zones whose name matches this [RE2](https://github.com/google/re2/wiki/Syntax) regular expression, e.g. `\\.example\\.com\\.$`.",
+				Optional:            true,
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Only return zones of this kind. One of `Native`, `Master`, `Slave`, `Producer` or `Consumer`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Native", "Master", "Slave", "Producer", "Consumer"),
+				},
+			},
+			"account": schema.StringAttribute{
+				MarkdownDescription: "Only return zones owned by this account.",
+				Optional:            true,
+			},
+			"catalog": schema.StringAttribute{
+				MarkdownDescription: "Only return zones that are members of this catalog zone.",
+				Optional:            true,
+			},
+			"include_dnssec": schema.BoolAttribute{
+				MarkdownDescription: "Whether PowerDNS should determine the `dnssec` flag of every zone. Setting this to `false` makes listing a lot faster on servers with many zones, but leaves `dnssec` `false`. Defaults to `true`.",
+				Optional:            true,
+			},
+			"names": schema.ListAttribute{
+				MarkdownDescription: "The names of all matching zones, sorted.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"zones": schema.ListNestedAttribute{
+				MarkdownDescription: "All matching zones, sorted by name.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the zone",
+							Computed:            true,
+						},
+						"kind": schema.StringAttribute{
+							MarkdownDescription: "The zone kind",
+							Computed:            true,
+						},
+						"serial": schema.Int64Attribute{
+							MarkdownDescription: "The current serial of the zone",
+							Computed:            true,
+						},
+						"notified_serial": schema.Int64Attribute{
+							MarkdownDescription: "The serial of the zone that was last sent to the secondaries with a NOTIFY",
+							Computed:            true,
+						},
+						"dnssec": schema.BoolAttribute{
+							MarkdownDescription: "Whether or not this zone is DNSSEC signed",
+							Computed:            true,
+						},
+						"account": schema.StringAttribute{
+							MarkdownDescription: "The account that owns the zone",
+							Computed:            true,
+						},
+						"catalog": schema.StringAttribute{
+							MarkdownDescription: "The catalog zone the zone is a member of",
+							Computed:            true,
+						},
+						"masters": schema.ListAttribute{
+							MarkdownDescription: "The primaries a secondary zone is transferred from",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ZonesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*PDNSProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError("Parse Error", "Failed to parse provider data")
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *ZonesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ZonesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+			return
+		}
+	}
+
+	includeDNSSec := data.IncludeDNSSec.IsNull() || data.IncludeDNSSec.ValueBool()
+
+	zones, err := d.providerData.pdnsClient.ListZones(ctx, data.Name.ValueString(), includeDNSSec)
+	if handleClientError(&resp.Diagnostics, err) {
+		return
+	}
+
+	zones = lo.Filter(zones, func(item pdns_client.PDNSZone, index int) bool {
+		return (nameRegex == nil || nameRegex.MatchString(item.Name)) &&
+			(data.Kind.IsNull() || item.Kind == data.Kind.ValueString()) &&
+			(data.Account.IsNull() || item.Account == data.Account.ValueString()) &&
+			(data.Catalog.IsNull() || item.Catalog == data.Catalog.ValueString())
+	})
+	slices.SortFunc(zones, func(a, b pdns_client.PDNSZone) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	summaries := make([]ZoneSummaryModel, 0, len(zones))
+	for _, zone := range zones {
+		masters, diags := types.ListValueFrom(ctx, types.StringType, lo.Ternary(zone.Masters == nil, []string{}, zone.Masters))
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		summaries = append(summaries, ZoneSummaryModel{
+			Name:           types.StringValue(zone.Name),
+			Kind:           types.StringValue(zone.Kind),
+			Serial:         types.Int64Value(zone.Serial),
+			NotifiedSerial: types.Int64Value(zone.NotifiedSerial),
+			DNSSec:         types.BoolValue(zone.Dnssec),
+			Account:        types.StringValue(zone.Account),
+			Catalog:        types.StringValue(zone.Catalog),
+			Masters:        masters,
+		})
+	}
+
+	zoneList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: ZoneSummaryModel{}.AttributeTypes()}, summaries)
+	resp.Diagnostics.Append(diags...)
+
+	names, diags := types.ListValueFrom(ctx, types.StringType, lo.Map(zones, func(item pdns_client.PDNSZone, index int) string {
+		return item.Name
+	}))
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Zones = zoneList
+	data.Names = names
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d matching zones", len(zones)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,98 @@
+package provider_test
+
+import (
+	"slices"
+	"testing"
+
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/acctest"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+func TestAccZonesDataSource(t *testing.T) {
+	d := acctest.NewDriver(t, nil)
+
+	for _, zone := range []pdns_client.PDNSZone{
+		{Name: "catalog.example.", Kind: "Producer"},
+		{Name: "a.example.com.", Kind: "Native", Account: "team-a", Catalog: "catalog.example."},
+		{Name: "b.example.com.", Kind: "Master", Account: "team-b", Catalog: "catalog.example."},
+		{Name: "example.org.", Kind: "Slave", Account: "team-a", Masters: []string{"192.0.2.1"}},
+	} {
+		d.Server.SetZone(zone)
+	}
+
+	tests := map[string]struct {
+		config    map[string]any
+		wantNames []string
+	}{
+		"all zones": {
+			config:    map[string]any{},
+			wantNames: []string{"a.example.com.", "b.example.com.", "catalog.example.", "example.org."},
+		},
+		"name": {
+			config:    map[string]any{"name": "b.example.com."},
+			wantNames: []string{"b.example.com."},
+		},
+		"name_regex": {
+			config:    map[string]any{"name_regex": `\.example\.com\.$`},
+			wantNames: []string{"a.example.com.", "b.example.com."},
+		},
+		"kind": {
+			config:    map[string]any{"kind": "Slave"},
+			wantNames: []string{"example.org."},
+		},
+		"account": {
+			config:    map[string]any{"account": "team-a"},
+			wantNames: []string{"a.example.com.", "example.org."},
+		},
+		"catalog": {
+			config:    map[string]any{"catalog": "catalog.example."},
+			wantNames: []string{"a.example.com.", "b.example.com."},
+		},
+		"combined filters": {
+			config:    map[string]any{"account": "team-a", "catalog": "catalog.example."},
+			wantNames: []string{"a.example.com."},
+		},
+		"no match": {
+			config:    map[string]any{"name_regex": `^nothing\.`},
+			wantNames: []string{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			state, err := d.ReadDataSource("pdns_zones", test.config)
+			if err != nil {
+				t.Fatalf("read: %s", err)
+			}
+			if got := acctest.StringsAttribute(state, "names"); !slices.Equal(got, test.wantNames) {
+				t.Errorf("names = %q, want %q", got, test.wantNames)
+			}
+
+			zones := acctest.ListAttribute(state, "zones")
+			if len(zones) != len(test.wantNames) {
+				t.Fatalf("got %d zones, want %d", len(zones), len(test.wantNames))
+			}
+			for i, zone := range zones {
+				if got := acctest.StringAttribute(zone, "name"); got != test.wantNames[i] {
+					t.Errorf("zones[%d].name = %q, want %q", i, got, test.wantNames[i])
+				}
+			}
+		})
+	}
+
+	state, err := d.ReadDataSource("pdns_zones", map[string]any{"kind": "Slave"})
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	zone := acctest.ListAttribute(state, "zones")[0]
+	if got := acctest.StringAttribute(zone, "account"); got != "team-a" {
+		t.Errorf("account = %q, want team-a", got)
+	}
+	if got := acctest.StringsAttribute(zone, "masters"); !slices.Equal(got, []string{"192.0.2.1"}) {
+		t.Errorf("masters = %q, want [192.0.2.1]", got)
+	}
+
+	if _, err := d.ReadDataSource("pdns_zones", map[string]any{"name_regex": "("}); err == nil {
+		t.Error("reading with an invalid name_regex succeeded, want error")
+	}
+}
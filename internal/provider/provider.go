@@ -4,12 +4,16 @@ import (
 	"context"
 	"crypto/tls"
 	"net/http"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
 )
@@ -20,18 +24,26 @@ var (
 )
 
 type PDNSProviderData struct {
-	pdnsClient *pdns_client.PDNSClient
+	pdnsClient    *pdns_client.PDNSClient
+	recordBatcher *pdns_client.RrsetBatcher
 }
 
+const defaultBatchWindow = 50 * time.Millisecond
+
 type PDNSProvider struct {
 	version string
 }
 
 type PDNSProviderModel struct {
-	Endpoint      types.String `tfsdk:"endpoint"`
-	APIKey        types.String `tfsdk:"api_key"`
-	ServerID      types.String `tfsdk:"server_id"`
-	SkipTLSVerify types.Bool   `tfsdk:"skip_tls_verify"`
+	Endpoint              types.String  `tfsdk:"endpoint"`
+	APIKey                types.String  `tfsdk:"api_key"`
+	ServerID              types.String  `tfsdk:"server_id"`
+	SkipTLSVerify         types.Bool    `tfsdk:"skip_tls_verify"`
+	MaxRetries            types.Int64   `tfsdk:"max_retries"`
+	RetryMaxWait          types.Int64   `tfsdk:"retry_max_wait"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	BatchWindow           types.Int64   `tfsdk:"batch_window"`
 }
 
 func (p *PDNSProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -62,6 +74,41 @@ func (p *PDNSProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 				Optional:            true,
 				Required:            false,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times an idempotent API request is retried after a transport error or a `429`/`5xx` response. Set to `0` to disable retries. Defaults to `3`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				MarkdownDescription: "Maximum time in seconds to wait between two retries. The wait grows exponentially with jitter up to this value; a `Retry-After` header sent by the server is honoured but capped as well. Defaults to `30`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum average number of API requests per second shared by all resources and data sources of this provider instance. Short bursts of up to one second worth of requests are allowed. Unlimited if unset or `0`.",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests in flight at the same time, shared by all resources and data sources of this provider instance. Unlimited if unset or `0`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"batch_window": schema.Int64Attribute{
+				MarkdownDescription: "Time in milliseconds during which changes of `pdns_record` and the typed record resources like `pdns_mx_record` for the same zone are collected and sent as a single PATCH request, resulting in one serial bump and one NOTIFY instead of one per record. Set to `0` to send every change on its own. Defaults to `50`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
 		},
 	}
 }
@@ -83,31 +130,69 @@ func (p *PDNSProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		data.SkipTLSVerify = types.BoolValue(false)
 	}
 
+	if data.MaxRetries.IsNull() {
+		data.MaxRetries = types.Int64Value(pdns_client.DefaultMaxRetries)
+	}
+
+	if data.RetryMaxWait.IsNull() {
+		data.RetryMaxWait = types.Int64Value(int64(pdns_client.DefaultRetryMaxWait / time.Second))
+	}
+
+	if data.BatchWindow.IsNull() {
+		data.BatchWindow = types.Int64Value(defaultBatchWindow.Milliseconds())
+	}
+
 	client := &http.Client{
 		Transport: &http.Transport{
 			TLSClientConfig: &tls.Config{InsecureSkipVerify: data.SkipTLSVerify.ValueBool()},
 		},
 	}
 
-	resp.ResourceData = &PDNSProviderData{
-		pdnsClient: pdns_client.NewPDNSClient(
-			client,
-			data.Endpoint.ValueString(),
-			data.ServerID.ValueString(),
-			data.APIKey.ValueString(),
+	pdnsClient := pdns_client.NewPDNSClient(
+		client,
+		data.Endpoint.ValueString(),
+		data.ServerID.ValueString(),
+		data.APIKey.ValueString(),
+		pdns_client.WithRetries(
+			int(data.MaxRetries.ValueInt64()),
+			pdns_client.DefaultRetryMinWait,
+			time.Duration(data.RetryMaxWait.ValueInt64())*time.Second,
 		),
+		pdns_client.WithRateLimit(data.RequestsPerSecond.ValueFloat64()),
+		pdns_client.WithMaxConcurrentRequests(int(data.MaxConcurrentRequests.ValueInt64())),
+	)
+
+	providerData := &PDNSProviderData{
+		pdnsClient:    pdnsClient,
+		recordBatcher: pdns_client.NewRrsetBatcher(pdnsClient, time.Duration(data.BatchWindow.ValueInt64())*time.Millisecond),
 	}
+
+	resp.ResourceData = providerData
+	resp.DataSourceData = providerData
 }
 
 func (p *PDNSProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewZoneResource,
 		NewRecordResource,
+		NewMXRecordResource,
+		NewSRVRecordResource,
+		NewCAARecordResource,
+		NewTXTRecordResource,
+		NewTLSARecordResource,
+		NewCryptokeyResource,
+		NewTSIGKeyResource,
+		NewZoneMetadataResource,
 	}
 }
 
 func (p *PDNSProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewZoneDataSource,
+		NewZonesDataSource,
+		NewRecordDataSource,
+		NewZoneDNSSecDSDataSource,
+	}
 }
 
 func (p *PDNSProvider) Functions(ctx context.Context) []func() function.Function {
@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// recordContentCanonicalizers reduce the content of a record to a form in
+// which contents PowerDNS treats as equal are equal, e.g. an IPv6 address
+// with and without leading zeros. The result is only used for comparison.
+// Contents of other types are compared with normalized whitespace.
+var recordContentCanonicalizers = map[string]func(content string) (string, error){
+	"A":     canonicalAddressContent,
+	"AAAA":  canonicalAddressContent,
+	"CNAME": canonicalHostnameContent,
+	"DNAME": canonicalHostnameContent,
+	"NS":    canonicalHostnameContent,
+	"PTR":   canonicalHostnameContent,
+	"MX":    canonicalMXContent,
+	"SRV":   canonicalSRVContent,
+	"TXT":   canonicalTXTContent,
+	"SPF":   canonicalTXTContent,
+	"CAA":   canonicalCAAContent,
+	"SSHFP": canonicalHexContent(2),
+	"TLSA":  canonicalHexContent(3),
+	"DS":    canonicalHexContent(3),
+	"HTTPS": canonicalSVCBContent,
+	"SVCB":  canonicalSVCBContent,
+	"LOC":   canonicalLOCContent,
+	"NAPTR": canonicalNAPTRContent,
+}
+
+// canonicalRecordContent returns the canonical form of content for rrType.
+// Content that can not be parsed is compared as is.
+func canonicalRecordContent(rrType string, content string) string {
+	canonicalize, ok := recordContentCanonicalizers[strings.ToUpper(rrType)]
+	if !ok {
+		return strings.Join(strings.Fields(content), " ")
+	}
+
+	canonical, err := canonicalize(content)
+	if err != nil {
+		return content
+	}
+	return canonical
+}
+
+// equivalentRecordContent returns the content out of prior that is
+// equivalent to current, so the form of the configuration is kept in state
+// when PowerDNS reports a normalized one. Returns current if there is none.
+func equivalentRecordContent(rrType string, prior []string, current string) string {
+	canonical := canonicalRecordContent(rrType, current)
+	for _, content := range prior {
+		if content == current || canonicalRecordContent(rrType, content) == canonical {
+			return content
+		}
+	}
+	return current
+}
+
+func canonicalAddressContent(content string) (string, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(content))
+	if err != nil {
+		return "", err
+	}
+	return addr.String(), nil
+}
+
+func canonicalHostnameContent(content string) (string, error) {
+	return strings.ToLower(strings.TrimSpace(content)), nil
+}
+
+func canonicalMXContent(content string) (string, error) {
+	return canonicalFields(content, "n", "h")
+}
+
+func canonicalSRVContent(content string) (string, error) {
+	return canonicalFields(content, "n", "n", "n", "h")
+}
+
+func canonicalCAAContent(content string) (string, error) {
+	return canonicalFields(content, "n", "l", "q")
+}
+
+func canonicalNAPTRContent(content string) (string, error) {
+	return canonicalFields(content, "n", "n", "q", "q", "q", "h")
+}
+
+// canonicalFields canonicalizes the fields of content by the given kinds:
+// `n` for numbers, `h` for hostnames, `l` for case insensitive tokens and `q`
+// for quoted strings.
+func canonicalFields(content string, kinds ...string) (string, error) {
+	fields, err := recordFields(content, len(kinds), len(kinds))
+	if err != nil {
+		return "", err
+	}
+
+	for i, kind := range kinds {
+		switch kind {
+		case "n":
+			number, err := strconv.ParseUint(fields[i], 10, 32)
+			if err != nil {
+				return "", err
+			}
+			fields[i] = strconv.FormatUint(number, 10)
+		case "h", "l":
+			fields[i] = strings.ToLower(fields[i])
+		case "q":
+			text, err := unquote(fields[i])
+			if err != nil {
+				return "", err
+			}
+			fields[i] = strconv.Quote(text)
+		}
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// canonicalTXTContent keeps the character strings of content, PowerDNS may
+// escape them differently than configured.
+func canonicalTXTContent(content string) (string, error) {
+	fields, err := recordFields(content, 1, -1)
+	if err != nil {
+		return "", err
+	}
+
+	for i, field := range fields {
+		text, err := unquote(field)
+		if err != nil {
+			return "", err
+		}
+		fields[i] = strconv.Quote(text)
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// canonicalHexContent handles SSHFP, TLSA and DS records, which consist of
+// the given count of numbers followed by hex data that may be split into
+// several fields.
+func canonicalHexContent(numbers int) func(content string) (string, error) {
+	return func(content string) (string, error) {
+		fields := strings.Fields(content)
+		if len(fields) <= numbers {
+			return "", fmt.Errorf("expected at least %d fields", numbers+1)
+		}
+
+		for i := range numbers {
+			number, err := strconv.ParseUint(fields[i], 10, 16)
+			if err != nil {
+				return "", err
+			}
+			fields[i] = strconv.FormatUint(number, 10)
+		}
+		return strings.Join(append(fields[:numbers], strings.ToLower(strings.Join(fields[numbers:], ""))), " "), nil
+	}
+}
+
+// canonicalSVCBContent sorts the parameters, which PowerDNS reports in the
+// order of their key numbers.
+func canonicalSVCBContent(content string) (string, error) {
+	fields, err := recordFields(content, 2, -1)
+	if err != nil {
+		return "", err
+	}
+
+	priority, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return "", err
+	}
+
+	params := make([]string, 0, len(fields)-2)
+	for _, param := range fields[2:] {
+		key, value, hasValue := strings.Cut(param, "=")
+		if strings.HasPrefix(value, `"`) {
+			if value, err = unquote(value); err != nil {
+				return "", err
+			}
+		}
+		params = append(params, strings.ToLower(key)+lo.Ternary(hasValue, "="+value, ""))
+	}
+	slices.Sort(params)
+
+	return strings.Join(append([]string{strconv.FormatUint(priority, 10), strings.ToLower(fields[1])}, params...), " "), nil
+}
+
+// canonicalLOCContent converts the coordinates into thousandths of an arc
+// second and the distances into centimeters, adding the defaults of RFC 1876
+// for omitted sizes and precisions like PowerDNS does.
+func canonicalLOCContent(content string) (string, error) {
+	fields := strings.Fields(content)
+
+	coordinate := func(hemispheres string) (int64, error) {
+		var value float64
+		for unit := 3600.0; len(fields) > 0 && !strings.Contains(hemispheres, fields[0]); unit /= 60 {
+			number, err := strconv.ParseFloat(fields[0], 64)
+			if err != nil {
+				return 0, err
+			}
+			value += number * unit
+			fields = fields[1:]
+		}
+		if len(fields) == 0 {
+			return 0, fmt.Errorf("missing hemisphere %s", hemispheres)
+		}
+		if fields[0] == hemispheres[1:] {
+			value = -value
+		}
+		fields = fields[1:]
+		return int64(math.Round(value * 1000)), nil
+	}
+
+	latitude, err := coordinate("NS")
+	if err != nil {
+		return "", err
+	}
+	longitude, err := coordinate("EW")
+	if err != nil {
+		return "", err
+	}
+
+	distances := []float64{0, 1, 10000, 10}
+	if len(fields) == 0 || len(fields) > len(distances) {
+		return "", fmt.Errorf("unexpected number of distances")
+	}
+	for i, field := range fields {
+		if distances[i], err = strconv.ParseFloat(strings.TrimSuffix(field, "m"), 64); err != nil {
+			return "", err
+		}
+	}
+
+	canonical := []string{strconv.FormatInt(latitude, 10), strconv.FormatInt(longitude, 10)}
+	for _, distance := range distances {
+		canonical = append(canonical, strconv.FormatInt(int64(math.Round(distance*100)), 10))
+	}
+	return strings.Join(canonical, " "), nil
+}
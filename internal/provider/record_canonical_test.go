@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestCanonicalRecordContent(t *testing.T) {
+	// equal holds contents PowerDNS treats as the same record, different ones
+	// it does not.
+	tests := map[string]struct {
+		equal     [][2]string
+		different [][2]string
+	}{
+		"A": {
+			equal:     [][2]string{{"192.0.2.1", " 192.0.2.1 "}},
+			different: [][2]string{{"10.0.0.1", "10.0.0.2"}},
+		},
+		"AAAA": {
+			equal:     [][2]string{{"2345:0425:2CA1:0000:0000:0567:5673:23b5", "2345:425:2ca1::567:5673:23b5"}},
+			different: [][2]string{{"2001:db8::1", "2001:db8::2"}},
+		},
+		"CNAME": {
+			equal:     [][2]string{{"WWW.Example.com.", "www.example.com."}},
+			different: [][2]string{{"www.example.com.", "www.example.com"}},
+		},
+		"DNAME": {
+			equal:     [][2]string{{"Example.NET.", "example.net."}},
+			different: [][2]string{{"example.net.", "example.org."}},
+		},
+		"NS": {
+			equal:     [][2]string{{"NS1.example.com.", "ns1.example.com."}},
+			different: [][2]string{{"ns1.example.com.", "ns2.example.com."}},
+		},
+		"PTR": {
+			equal:     [][2]string{{"Host.example.com.", "host.example.com."}},
+			different: [][2]string{{"a.example.com.", "b.example.com."}},
+		},
+		"MX": {
+			equal:     [][2]string{{"010 Mail.Example.com.", "10 mail.example.com."}},
+			different: [][2]string{{"10 mail.example.com.", "20 mail.example.com."}},
+		},
+		"SRV": {
+			equal:     [][2]string{{"10  5 05060 SIP.example.com.", "10 5 5060 sip.example.com."}},
+			different: [][2]string{{"10 5 5060 sip.example.com.", "10 5 5061 sip.example.com."}},
+		},
+		"TXT": {
+			equal:     [][2]string{{`"a\"b" "\065"`, `"a\034b"  "A"`}},
+			different: [][2]string{{`"a" "b"`, `"ab"`}, {`"A"`, `"a"`}},
+		},
+		"SPF": {
+			equal:     [][2]string{{`"v=spf1 \045all"`, `"v=spf1 -all"`}},
+			different: [][2]string{{`"v=spf1 -all"`, `"v=spf1 ~all"`}},
+		},
+		"CAA": {
+			equal:     [][2]string{{`0 ISSUE "x"`, `0 issue "x"`}},
+			different: [][2]string{{`0 issue "X"`, `0 issue "x"`}, {`0 issue "x"`, `128 issue "x"`}},
+		},
+		"SSHFP": {
+			equal:     [][2]string{{"4 2 ABCD 1234", "4 2 abcd1234"}},
+			different: [][2]string{{"4 2 abcd", "4 1 abcd"}},
+		},
+		"TLSA": {
+			equal:     [][2]string{{"3 1 1 1234", "3 1 1 1234"}, {"3 1 1 AB CD", "3 1 1 abcd"}},
+			different: [][2]string{{"3 1 1 abcd", "3 1 2 abcd"}},
+		},
+		"DS": {
+			equal:     [][2]string{{"12345 13 2 ABCD 1234", "12345 13 2 abcd1234"}},
+			different: [][2]string{{"12345 13 2 ab", "12345 13 1 ab"}},
+		},
+		"HTTPS": {
+			equal:     [][2]string{{`1 . port=443 alpn="h2,h3"`, `1 . alpn=h2,h3 port=443`}},
+			different: [][2]string{{"1 . alpn=h2", "1 . alpn=h3"}},
+		},
+		"SVCB": {
+			equal:     [][2]string{{`1 Svc.example.com. port="8443"`, `1 svc.example.com. port=8443`}},
+			different: [][2]string{{"1 svc.example.com.", "2 svc.example.com."}},
+		},
+		"LOC": {
+			equal: [][2]string{
+				{"52 N 4 E 10m", "52 0 0.000 N 4 0 0.000 E 10.00m 1.00m 10000.00m 10.00m"},
+				{"51 30 12.748 S 0 7 39.611 W 0m", "51 30 12.748 S 0 7 39.611 W 0.00m 1m 10000m 10m"},
+			},
+			different: [][2]string{{"52 N 4 E 10m", "52 S 4 E 10m"}},
+		},
+		"NAPTR": {
+			equal:     [][2]string{{`100 10 "S" "SIP+D2U" "" _SIP._udp.example.com.`, `100 10 "S" "SIP+D2U" "" _sip._udp.example.com.`}},
+			different: [][2]string{{`100 10 "S" "SIP+D2U" "" .`, `100 20 "S" "SIP+D2U" "" .`}},
+		},
+		// Types without a canonicalizer only ignore whitespace.
+		"OPENPGPKEY": {
+			equal:     [][2]string{{"a  b", "a b"}},
+			different: [][2]string{{"A", "a"}},
+		},
+	}
+
+	for rrType := range recordContentCanonicalizers {
+		if _, ok := tests[rrType]; !ok {
+			t.Errorf("no test for the canonical %s content", rrType)
+		}
+	}
+
+	for rrType, test := range tests {
+		t.Run(rrType, func(t *testing.T) {
+			for _, pair := range test.equal {
+				if a, b := canonicalRecordContent(rrType, pair[0]), canonicalRecordContent(rrType, pair[1]); a != b {
+					t.Errorf("%q and %q are canonically %q and %q, want them equal", pair[0], pair[1], a, b)
+				}
+			}
+			for _, pair := range test.different {
+				if canonical := canonicalRecordContent(rrType, pair[0]); canonical == canonicalRecordContent(rrType, pair[1]) {
+					t.Errorf("%q and %q are both canonically %q, want them different", pair[0], pair[1], canonical)
+				}
+			}
+		})
+	}
+}
+
+func TestCanonicalRecordContent_unparsable(t *testing.T) {
+	for _, test := range []struct{ rrType, content string }{
+		{rrType: "AAAA", content: "not an address"},
+		{rrType: "MX", content: "mail.example.com."},
+		{rrType: "LOC", content: "somewhere"},
+	} {
+		if got := canonicalRecordContent(test.rrType, test.content); got != test.content {
+			t.Errorf("%s %q: canonical form %q, want the content unchanged", test.rrType, test.content, got)
+		}
+	}
+}
+
+func TestEquivalentRecordContent(t *testing.T) {
+	tests := map[string]struct {
+		rrType  string
+		prior   []string
+		current string
+		want    string
+	}{
+		"configured form is kept": {
+			rrType:  "AAAA",
+			prior:   []string{"2001:DB8::0001", "2001:db8::2"},
+			current: "2001:db8::1",
+			want:    "2001:DB8::0001",
+		},
+		"identical content": {
+			rrType:  "A",
+			prior:   []string{"192.0.2.1"},
+			current: "192.0.2.1",
+			want:    "192.0.2.1",
+		},
+		"changed outside of Terraform": {
+			rrType:  "MX",
+			prior:   []string{"10 mail.example.com."},
+			current: "20 mail.example.com.",
+			want:    "20 mail.example.com.",
+		},
+		"no prior contents": {
+			rrType:  "CNAME",
+			current: "www.example.com.",
+			want:    "www.example.com.",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := equivalentRecordContent(test.rrType, test.prior, test.current); got != test.want {
+				t.Errorf("equivalentRecordContent(%q, %q, %q) = %q, want %q", test.rrType, test.prior, test.current, got, test.want)
+			}
+		})
+	}
+}
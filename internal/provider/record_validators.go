@@ -0,0 +1,447 @@
+package provider
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// recordContentValidators check the content of a single record of a type in
+// the presentation format PowerDNS expects. Types without a validator are
+// left to PowerDNS.
+var recordContentValidators = map[string]func(content string) error{
+	"A":     validateAContent,
+	"AAAA":  validateAAAAContent,
+	"CNAME": validateHostnameContent,
+	"NS":    validateHostnameContent,
+	"PTR":   validateHostnameContent,
+	"MX":    validateMXContent,
+	"SRV":   validateSRVContent,
+	"TXT":   validateTXTContent,
+	"SPF":   validateTXTContent,
+	"CAA":   validateCAAContent,
+	"SSHFP": validateSSHFPContent,
+	"TLSA":  validateTLSAContent,
+	"DS":    validateDSContent,
+	"HTTPS": validateSVCBContent,
+	"SVCB":  validateSVCBContent,
+	"LOC":   validateLOCContent,
+	"NAPTR": validateNAPTRContent,
+}
+
+// validateRecordContent checks content against the validator of rrType, if
+// there is one.
+func validateRecordContent(rrType string, content string) error {
+	validate, ok := recordContentValidators[strings.ToUpper(rrType)]
+	if !ok {
+		return nil
+	}
+	return validate(content)
+}
+
+var CAA_TAG_REGEX = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+var SVCB_KEY_REGEX = regexp.MustCompile(`^(mandatory|alpn|no-default-alpn|port|ipv4hint|ech|ipv6hint|dohpath|ohttp|key[0-9]{1,5})$`)
+
+func validateAContent(content string) error {
+	addr, err := netip.ParseAddr(content)
+	if err != nil || !addr.Is4() {
+		return errors.New("the content must be an IPv4 address, e.g. `192.0.2.1`")
+	}
+	return nil
+}
+
+func validateAAAAContent(content string) error {
+	addr, err := netip.ParseAddr(content)
+	if err != nil || !addr.Is6() || addr.Zone() != "" {
+		return errors.New("the content must be an IPv6 address, e.g. `2001:db8::1`")
+	}
+	return nil
+}
+
+func validateHostnameContent(content string) error {
+	fields, err := recordFields(content, 1, 1)
+	if err != nil {
+		return err
+	}
+	return validateHostname(fields[0], "the target", false)
+}
+
+func validateMXContent(content string) error {
+	fields, err := recordFields(content, 2, 2)
+	if err != nil {
+		return errors.New("the content must be `<priority> <exchange>`, e.g. `10 mail.example.com.`")
+	}
+	if err := validateUint(fields[0], "the priority", 16); err != nil {
+		return err
+	}
+	return validateHostname(fields[1], "the exchange", true)
+}
+
+func validateSRVContent(content string) error {
+	fields, err := recordFields(content, 4, 4)
+	if err != nil {
+		return errors.New("the content must be `<priority> <weight> <port> <target>`, e.g. `10 5 5060 sip.example.com.`")
+	}
+	for i, name := range []string{"the priority", "the weight", "the port"} {
+		if err := validateUint(fields[i], name, 16); err != nil {
+			return err
+		}
+	}
+	return validateHostname(fields[3], "the target", true)
+}
+
+func validateTXTContent(content string) error {
+	fields, err := recordFields(content, 1, -1)
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if err := validateCharacterString(field, "each string"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateCAAContent(content string) error {
+	fields, err := recordFields(content, 3, 3)
+	if err != nil {
+		return errors.New("the content must be `<flags> <tag> \"<value>\"`, e.g. `0 issue \"letsencrypt.org\"`")
+	}
+	if err := validateUint(fields[0], "the flags", 8); err != nil {
+		return err
+	}
+	if !CAA_TAG_REGEX.MatchString(fields[1]) {
+		return fmt.Errorf("the tag %q must only contain letters and digits, e.g. `issue`", fields[1])
+	}
+	if _, err := unquote(fields[2]); err != nil {
+		return fmt.Errorf("the value %s", err)
+	}
+	return nil
+}
+
+func validateSSHFPContent(content string) error {
+	fields, err := recordFields(content, 3, 3)
+	if err != nil {
+		return errors.New("the content must be `<algorithm> <type> <fingerprint>`, e.g. `4 2 123456789abcdef...`")
+	}
+	if err := validateUint(fields[0], "the algorithm", 8); err != nil {
+		return err
+	}
+	if err := validateUint(fields[1], "the fingerprint type", 8); err != nil {
+		return err
+	}
+	lengths := map[string]int{"1": 40, "2": 64}
+	return validateHex(fields[2], "the fingerprint", lengths[fields[1]])
+}
+
+func validateTLSAContent(content string) error {
+	fields, err := recordFields(content, 4, 4)
+	if err != nil {
+		return errors.New("the content must be `<usage> <selector> <matching type> <data>`, e.g. `3 1 1 0123456789abcdef...`")
+	}
+	for i, field := range []struct {
+		name string
+		max  uint64
+	}{{"the usage", 3}, {"the selector", 1}, {"the matching type", 2}} {
+		value, err := strconv.ParseUint(fields[i], 10, 8)
+		if err != nil || value > field.max {
+			return fmt.Errorf("%s must be a number between 0 and %d, got %q", field.name, field.max, fields[i])
+		}
+	}
+	lengths := map[string]int{"1": 64, "2": 128}
+	return validateHex(fields[3], "the certificate association data", lengths[fields[2]])
+}
+
+func validateDSContent(content string) error {
+	fields := strings.Fields(content)
+	if len(fields) < 4 {
+		return errors.New("the content must be `<key tag> <algorithm> <digest type> <digest>`, e.g. `12345 13 2 0123456789abcdef...`")
+	}
+	if err := validateUint(fields[0], "the key tag", 16); err != nil {
+		return err
+	}
+	if err := validateUint(fields[1], "the algorithm", 8); err != nil {
+		return err
+	}
+	if err := validateUint(fields[2], "the digest type", 8); err != nil {
+		return err
+	}
+	lengths := map[string]int{"1": 40, "2": 64, "4": 96}
+	return validateHex(strings.Join(fields[3:], ""), "the digest", lengths[fields[2]])
+}
+
+func validateSVCBContent(content string) error {
+	fields, err := recordFields(content, 2, -1)
+	if err != nil {
+		return errors.New("the content must be `<priority> <target> [<key>=<value> ...]`, e.g. `1 . alpn=h2,h3`")
+	}
+	if err := validateUint(fields[0], "the priority", 16); err != nil {
+		return err
+	}
+	if err := validateHostname(fields[1], "the target", true); err != nil {
+		return err
+	}
+	if fields[0] == "0" && len(fields) > 2 {
+		return errors.New("a record in alias mode (priority 0) must not have parameters")
+	}
+
+	for _, param := range fields[2:] {
+		key, value, hasValue := strings.Cut(param, "=")
+		if !SVCB_KEY_REGEX.MatchString(key) {
+			return fmt.Errorf("unknown parameter %q", key)
+		}
+		if key == "no-default-alpn" {
+			if hasValue {
+				return errors.New("the parameter no-default-alpn must not have a value")
+			}
+			continue
+		}
+		if !hasValue || value == "" {
+			return fmt.Errorf("the parameter %s requires a value", key)
+		}
+
+		if strings.HasPrefix(value, `"`) {
+			unquoted, err := unquote(value)
+			if err != nil {
+				return fmt.Errorf("the value of %s %s", key, err)
+			}
+			value = unquoted
+		}
+
+		switch key {
+		case "port":
+			if err := validateUint(value, "the port", 16); err != nil {
+				return err
+			}
+		case "ipv4hint", "ipv6hint":
+			for _, hint := range strings.Split(value, ",") {
+				addr, err := netip.ParseAddr(hint)
+				if err != nil || (key == "ipv4hint") != addr.Is4() {
+					return fmt.Errorf("%q is not a valid address for %s", hint, key)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateLOCContent checks the format of RFC 1876, e.g.
+// `51 30 12.748 N 0 7 39.611 W 0.00m 0.00m 0.00m 0.00m`.
+func validateLOCContent(content string) error {
+	fields := strings.Fields(content)
+	i := 0
+
+	coordinate := func(name string, maxDegrees int64, hemispheres string) error {
+		var parts []string
+		for i < len(fields) && len(parts) < 3 && !strings.Contains(hemispheres, fields[i]) {
+			parts = append(parts, fields[i])
+			i++
+		}
+		if len(parts) == 0 || i >= len(fields) || len(fields[i]) != 1 || !strings.Contains(hemispheres, fields[i]) {
+			return fmt.Errorf("the %s must be `<degrees> [<minutes> [<seconds>]] %s`", name, strings.Join(strings.Split(hemispheres, ""), "|"))
+		}
+		i++
+
+		degrees, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil || degrees < 0 || degrees > maxDegrees {
+			return fmt.Errorf("the degrees of the %s must be a number between 0 and %d, got %q", name, maxDegrees, parts[0])
+		}
+		if len(parts) > 1 {
+			minutes, err := strconv.ParseInt(parts[1], 10, 64)
+			if err != nil || minutes < 0 || minutes > 59 {
+				return fmt.Errorf("the minutes of the %s must be a number between 0 and 59, got %q", name, parts[1])
+			}
+		}
+		if len(parts) > 2 {
+			seconds, err := strconv.ParseFloat(parts[2], 64)
+			if err != nil || seconds < 0 || seconds >= 60 {
+				return fmt.Errorf("the seconds of the %s must be a number between 0 and 59.999, got %q", name, parts[2])
+			}
+		}
+		return nil
+	}
+
+	if err := coordinate("latitude", 90, "NS"); err != nil {
+		return err
+	}
+	if err := coordinate("longitude", 180, "EW"); err != nil {
+		return err
+	}
+
+	meters := []struct {
+		name     string
+		min, max float64
+	}{
+		{"the altitude", -100000, 42849672.95},
+		{"the size", 0, 90000000},
+		{"the horizontal precision", 0, 90000000},
+		{"the vertical precision", 0, 90000000},
+	}
+	if i >= len(fields) {
+		return errors.New("the altitude is missing")
+	}
+	if len(fields)-i > len(meters) {
+		return fmt.Errorf("unexpected field %q", fields[i+len(meters)])
+	}
+	for j, field := range fields[i:] {
+		value, err := strconv.ParseFloat(strings.TrimSuffix(field, "m"), 64)
+		if err != nil || value < meters[j].min || value > meters[j].max {
+			return fmt.Errorf("%s must be between %.2fm and %.2fm, got %q", meters[j].name, meters[j].min, meters[j].max, field)
+		}
+	}
+	return nil
+}
+
+func validateNAPTRContent(content string) error {
+	fields, err := recordFields(content, 6, 6)
+	if err != nil {
+		return errors.New("the content must be `<order> <preference> \"<flags>\" \"<service>\" \"<regexp>\" <replacement>`, e.g. `100 10 \"S\" \"SIP+D2U\" \"\" _sip._udp.example.com.`")
+	}
+	if err := validateUint(fields[0], "the order", 16); err != nil {
+		return err
+	}
+	if err := validateUint(fields[1], "the preference", 16); err != nil {
+		return err
+	}
+	for i, name := range []string{"the flags", "the service", "the regexp"} {
+		if err := validateCharacterString(fields[2+i], name); err != nil {
+			return err
+		}
+	}
+	return validateHostname(fields[5], "the replacement", true)
+}
+
+// recordFields splits content at whitespace outside of double quotes and
+// checks that there are between min and max fields, where a negative max
+// means no limit. Quotes are kept in the fields.
+func recordFields(content string, min int, max int) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	quoted, escaped := false, false
+
+	for _, char := range content {
+		switch {
+		case escaped:
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '"':
+			quoted = !quoted
+		case !quoted && (char == ' ' || char == '\t'):
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+			continue
+		}
+		field.WriteRune(char)
+	}
+	if quoted {
+		return nil, errors.New("the content has an unterminated quote")
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+
+	if len(fields) < min || (max >= 0 && len(fields) > max) {
+		if min == max {
+			return nil, fmt.Errorf("the content must consist of %d field(s), got %d", min, len(fields))
+		}
+		return nil, fmt.Errorf("the content must consist of at least %d field(s), got %d", min, len(fields))
+	}
+	return fields, nil
+}
+
+// unquote returns the text of a double quoted string with its escapes, e.g.
+// `\"` or `\032`, resolved.
+func unquote(field string) (string, error) {
+	if len(field) < 2 || !strings.HasPrefix(field, `"`) || !strings.HasSuffix(field, `"`) {
+		return "", fmt.Errorf("must be enclosed in double quotes, e.g. \"%s\"", strings.Trim(field, `"`))
+	}
+
+	var text strings.Builder
+	inner := field[1 : len(field)-1]
+	for i := 0; i < len(inner); i++ {
+		if inner[i] != '\\' {
+			text.WriteByte(inner[i])
+			continue
+		}
+		if i+3 < len(inner) && isDigits(inner[i+1:i+4]) {
+			value, _ := strconv.ParseUint(inner[i+1:i+4], 10, 16)
+			if value > 255 {
+				return "", fmt.Errorf("has an invalid escape \\%s", inner[i+1:i+4])
+			}
+			text.WriteByte(byte(value))
+			i += 3
+			continue
+		}
+		if i+1 >= len(inner) {
+			return "", errors.New("ends with an incomplete escape")
+		}
+		text.WriteByte(inner[i+1])
+		i++
+	}
+	return text.String(), nil
+}
+
+func isDigits(value string) bool {
+	return strings.Trim(value, "0123456789") == ""
+}
+
+func validateCharacterString(field string, name string) error {
+	text, err := unquote(field)
+	if err != nil {
+		return fmt.Errorf("%s %s", name, err)
+	}
+	if len(text) > 255 {
+		return fmt.Errorf("%s must not be longer than 255 bytes, split it into multiple quoted strings", name)
+	}
+	return nil
+}
+
+func validateUint(field string, name string, bits int) error {
+	if _, err := strconv.ParseUint(field, 10, bits); err != nil {
+		return fmt.Errorf("%s must be a number between 0 and %d, got %q", name, uint64(1)<<bits-1, field)
+	}
+	return nil
+}
+
+func validateHex(field string, name string, length int) error {
+	if _, err := hex.DecodeString(field); err != nil || field == "" {
+		return fmt.Errorf("%s must be hex encoded, got %q", name, field)
+	}
+	if length > 0 && len(field) != length {
+		return fmt.Errorf("%s must be %d hex digits long for this type, got %d", name, length, len(field))
+	}
+	return nil
+}
+
+// validateHostname checks that field is a fully qualified name ending with a
+// dot, as PowerDNS requires for names in record content. allowRoot permits
+// `.` on its own, e.g. for a null MX.
+func validateHostname(field string, name string, allowRoot bool) error {
+	if field == "." {
+		if allowRoot {
+			return nil
+		}
+		return fmt.Errorf("%s must not be the root `.`", name)
+	}
+	if !strings.HasSuffix(field, ".") {
+		return fmt.Errorf("%s %q must be fully qualified and end with a dot, e.g. %q", name, field, field+".")
+	}
+	if len(field) > 254 {
+		return fmt.Errorf("%s must not be longer than 253 characters", name)
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(field, "."), ".") {
+		if label == "" || len(label) > 63 {
+			return fmt.Errorf("%s %q must consist of labels of 1 to 63 characters", name, field)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestValidateRecordContent(t *testing.T) {
+	hex64 := strings.Repeat("ab", 32)
+
+	tests := map[string]struct {
+		valid   []string
+		invalid []string
+	}{
+		"A": {
+			valid:   []string{"192.0.2.1", "10.0.0.255"},
+			invalid: []string{"", "not-an-ip", "2001:db8::1", "192.0.2.256"},
+		},
+		"AAAA": {
+			valid:   []string{"2001:db8::1", "::ffff:1.2.3.4", "2345:0425:2CA1:0000:0000:0567:5673:23b5"},
+			invalid: []string{"192.0.2.1", "fe80::1%eth0", "2001:db8::g"},
+		},
+		"CNAME": {
+			valid:   []string{"www.example.com.", "Mixed.Case.example."},
+			invalid: []string{"www.example.com", ".", "a b.", "a..example.com.", strings.Repeat("a", 64) + ".example.com."},
+		},
+		"MX": {
+			valid:   []string{"10 mail.example.com.", "0 ."},
+			invalid: []string{"mail.example.com.", "70000 mail.example.com.", "10 mail", "10 mail.example.com. extra"},
+		},
+		"SRV": {
+			valid:   []string{"10 5 5060 sip.example.com.", "0 0 0 ."},
+			invalid: []string{"10 5 sip.example.com.", "10 5 70000 sip.example.com.", "10 5 5060 sip"},
+		},
+		"TXT": {
+			valid:   []string{`"v=spf1 -all"`, `"a" "b c"`, `"esc \" q"`, `"\065"`, `""`},
+			invalid: []string{"v=spf1", `"unterminated`, `"` + strings.Repeat("a", 256) + `"`},
+		},
+		"CAA": {
+			valid:   []string{`0 issue "letsencrypt.org"`, `128 iodef "mailto:a@b.c"`},
+			invalid: []string{`0 issue letsencrypt.org`, `0 is-sue "x"`, `256 issue "x"`},
+		},
+		"SSHFP": {
+			valid:   []string{"4 2 " + hex64, "1 1 " + strings.Repeat("a", 40)},
+			invalid: []string{"4 2 abc", "4 2 " + strings.Repeat("a", 40), "4 2 zz"},
+		},
+		"TLSA": {
+			valid:   []string{"3 1 1 " + hex64, "3 0 0 abcd"},
+			invalid: []string{"4 1 1 " + hex64, "3 1 1 zz", "3 1 1 " + hex64[:10]},
+		},
+		"DS": {
+			valid:   []string{"12345 13 2 " + hex64, "12345 13 2 " + hex64[:32] + " " + hex64[32:]},
+			invalid: []string{"12345 13 2", "12345 13 2 " + strings.Repeat("a", 40)},
+		},
+		"HTTPS": {
+			valid:   []string{"1 . alpn=h2,h3", "0 svc.example.com.", `1 . alpn="h2,h3" port=443 ipv4hint=192.0.2.1,192.0.2.2 no-default-alpn`},
+			invalid: []string{"1 svc.example.com", "0 . alpn=h2", "1 . foo=bar", "1 . port=abc", "1 . ipv4hint=::1", "1 . alpn"},
+		},
+		"LOC": {
+			valid:   []string{"51 30 12.748 N 0 7 39.611 W 0.00m 0.00m 0.00m 0.00m", "52 N 4 E 10m", "42 21 54 N 71 06 18 W -24m 30m"},
+			invalid: []string{"51 30 N 0 7 W", "91 N 0 E 0m", "51 30 12 X 0 7 39 W 0m", "51 N 0 E 0m 1m 1m 1m 1m"},
+		},
+		"NAPTR": {
+			valid:   []string{`100 10 "S" "SIP+D2U" "" _sip._udp.example.com.`, `100 10 "U" "E2U+sip" "!^.*$!sip:a@b!" .`},
+			invalid: []string{`100 10 S "SIP" "" .`, `100 10 "S" "SIP" "" x`},
+		},
+		// Types without a validator are left to PowerDNS.
+		"OPENPGPKEY": {
+			valid: []string{"anything goes"},
+		},
+	}
+
+	for rrType, test := range tests {
+		t.Run(rrType, func(t *testing.T) {
+			for _, content := range test.valid {
+				if err := validateRecordContent(rrType, content); err != nil {
+					t.Errorf("%q: unexpected error: %s", content, err)
+				}
+			}
+			for _, content := range test.invalid {
+				if err := validateRecordContent(rrType, content); err == nil {
+					t.Errorf("%q: expected an error", content)
+				}
+			}
+		})
+	}
+
+	if err := validateRecordContent("mx", "mail.example.com."); err == nil {
+		t.Error("lower-case types must be validated as well")
+	}
+}
+
+func TestRecordResourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+
+	r := &RecordResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	recordType := objectType.AttributeTypes["record"].(tftypes.Set).ElementType
+	record := func(content string) tftypes.Value {
+		return tftypes.NewValue(recordType, map[string]tftypes.Value{
+			"content":  tftypes.NewValue(tftypes.String, content),
+			"disabled": tftypes.NewValue(tftypes.Bool, nil),
+		})
+	}
+
+	recordElementType := schemaResp.Schema.Attributes["record"].GetType().(types.SetType).ElemType
+	recordValue := func(content string) attr.Value {
+		value, err := recordElementType.ValueFromTerraform(ctx, record(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return value
+	}
+
+	config := func(rrType string, records []string, recordSet []tftypes.Value) tftypes.Value {
+		values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attributeType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+		values["zone"] = tftypes.NewValue(tftypes.String, "example.com.")
+		values["name"] = tftypes.NewValue(tftypes.String, "www")
+		values["type"] = tftypes.NewValue(tftypes.String, rrType)
+		if records != nil {
+			elements := make([]tftypes.Value, len(records))
+			for i, content := range records {
+				elements[i] = tftypes.NewValue(tftypes.String, content)
+			}
+			values["records"] = tftypes.NewValue(objectType.AttributeTypes["records"], elements)
+		}
+		if recordSet != nil {
+			values["record"] = tftypes.NewValue(objectType.AttributeTypes["record"], recordSet)
+		}
+		return tftypes.NewValue(objectType, values)
+	}
+
+	tests := map[string]struct {
+		config    tftypes.Value
+		wantPaths []path.Path
+	}{
+		"valid records": {
+			config: config("MX", []string{"10 mail.example.com.", "20 backup.example.com."}, nil),
+		},
+		"invalid entries of records by index": {
+			config:    config("MX", []string{"10 mail.example.com.", "mail.example.com.", "20 backup.example.com.", "30 backup"}, nil),
+			wantPaths: []path.Path{path.Root("records").AtListIndex(1), path.Root("records").AtListIndex(3)},
+		},
+		"invalid entry of record": {
+			config: config("A", nil, []tftypes.Value{record("192.0.2.1"), record("192.0.2.300")}),
+			wantPaths: []path.Path{
+				path.Root("record").AtSetValue(recordValue("192.0.2.300")).AtName("content"),
+			},
+		},
+		"several CNAME records": {
+			config:    config("CNAME", []string{"a.example.com.", "b.example.com."}, nil),
+			wantPaths: []path.Path{path.Root("records")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: test.config}}
+			var resp resource.ValidateConfigResponse
+			r.ValidateConfig(ctx, req, &resp)
+
+			var gotPaths []path.Path
+			for _, diagnostic := range resp.Diagnostics.Errors() {
+				withPath, ok := diagnostic.(diag.DiagnosticWithPath)
+				if !ok {
+					t.Fatalf("diagnostic without attribute path: %s: %s", diagnostic.Summary(), diagnostic.Detail())
+				}
+				gotPaths = append(gotPaths, withPath.Path())
+			}
+
+			if len(gotPaths) != len(test.wantPaths) {
+				t.Fatalf("error paths = %v, want %v", gotPaths, test.wantPaths)
+			}
+			for i := range gotPaths {
+				if !gotPaths[i].Equal(test.wantPaths[i]) {
+					t.Errorf("error path %d = %s, want %s", i, gotPaths[i], test.wantPaths[i])
+				}
+			}
+		})
+	}
+}
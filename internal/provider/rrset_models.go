@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+	"gitlab.com/joelMuehlena/homelab/code/terraform/provider/terraform-provider-pdns/internal/pdns_client"
+)
+
+type RrsetModel struct {
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Records  types.List   `tfsdk:"records"`
+	Comments types.List   `tfsdk:"comments"`
+}
+
+func (m RrsetModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":     types.StringType,
+		"type":     types.StringType,
+		"ttl":      types.Int64Type,
+		"records":  types.ListType{ElemType: types.ObjectType{AttrTypes: RrsetRecordModel{}.AttributeTypes()}},
+		"comments": types.ListType{ElemType: types.ObjectType{AttrTypes: RrsetCommentModel{}.AttributeTypes()}},
+	}
+}
+
+type RrsetRecordModel struct {
+	Content  types.String `tfsdk:"content"`
+	Disabled types.Bool   `tfsdk:"disabled"`
+}
+
+func (m RrsetRecordModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"content":  types.StringType,
+		"disabled": types.BoolType,
+	}
+}
+
+type RrsetCommentModel struct {
+	Content    types.String `tfsdk:"content"`
+	Account    types.String `tfsdk:"account"`
+	ModifiedAt types.Int64  `tfsdk:"modified_at"`
+}
+
+func (m RrsetCommentModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"content":     types.StringType,
+		"account":     types.StringType,
+		"modified_at": types.Int64Type,
+	}
+}
+
+func recordsToList(ctx context.Context, records []pdns_client.Record) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(
+		ctx,
+		types.ObjectType{AttrTypes: RrsetRecordModel{}.AttributeTypes()},
+		lo.Map(records, func(item pdns_client.Record, index int) RrsetRecordModel {
+			return RrsetRecordModel{
+				Content:  types.StringValue(item.Content),
+				Disabled: types.BoolValue(item.Disabled),
+			}
+		}),
+	)
+}
+
+func commentsToList(ctx context.Context, comments []pdns_client.Comment) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(
+		ctx,
+		types.ObjectType{AttrTypes: RrsetCommentModel{}.AttributeTypes()},
+		lo.Map(comments, func(item pdns_client.Comment, index int) RrsetCommentModel {
+			return RrsetCommentModel{
+				Content:    types.StringValue(item.Content),
+				Account:    types.StringValue(item.Account),
+				ModifiedAt: types.Int64Value(item.ModifiedAt),
+			}
+		}),
+	)
+}
+
+func rrsetsToList(ctx context.Context, rrsets []pdns_client.Rrset) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	models := make([]RrsetModel, 0, len(rrsets))
+	for _, rrset := range rrsets {
+		records, d := recordsToList(ctx, rrset.Records)
+		diags.Append(d...)
+		comments, d := commentsToList(ctx, rrset.Comments)
+		diags.Append(d...)
+		if diags.HasError() {
+			return types.ListNull(types.ObjectType{AttrTypes: RrsetModel{}.AttributeTypes()}), diags
+		}
+
+		models = append(models, RrsetModel{
+			Name:     types.StringValue(rrset.Name),
+			Type:     types.StringValue(rrset.Type),
+			TTL:      types.Int64Value(rrset.TTL),
+			Records:  records,
+			Comments: comments,
+		})
+	}
+
+	list, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: RrsetModel{}.AttributeTypes()}, models)
+	diags.Append(d...)
+	return list, diags
+}
+
+func recordsDataSourceAttribute() dsschema.ListNestedAttribute {
+	return dsschema.ListNestedAttribute{
+		MarkdownDescription: "The records of the rrset.",
+		Computed:            true,
+		NestedObject: dsschema.NestedAttributeObject{
+			Attributes: map[string]dsschema.Attribute{
+				"content": dsschema.StringAttribute{
+					MarkdownDescription: "Content of the record as returned by PowerDNS.",
+					Computed:            true,
+				},
+				"disabled": dsschema.BoolAttribute{
+					MarkdownDescription: "Whether the record is disabled and therefore not served.",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+func commentsDataSourceAttribute() dsschema.ListNestedAttribute {
+	return dsschema.ListNestedAttribute{
+		MarkdownDescription: "The comments attached to the rrset.",
+		Computed:            true,
+		NestedObject: dsschema.NestedAttributeObject{
+			Attributes: map[string]dsschema.Attribute{
+				"content": dsschema.StringAttribute{
+					MarkdownDescription: "Text of the comment.",
+					Computed:            true,
+				},
+				"account": dsschema.StringAttribute{
+					MarkdownDescription: "Account that wrote the comment.",
+					Computed:            true,
+				},
+				"modified_at": dsschema.Int64Attribute{
+					MarkdownDescription: "Unix timestamp of the last change of the comment.",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
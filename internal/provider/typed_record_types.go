@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var HEX_REGEX = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+var mxRecordType = typedRecordType{
+	rrType:      "MX",
+	description: "Manages the MX records (rrset) of a name within a PowerDNS zone, with the priority and exchange as separate attributes.",
+	attributes: map[string]schema.Attribute{
+		"priority": uint16Attribute("Priority of the mail exchange, lower values are preferred."),
+		"exchange": hostnameAttribute("Hostname of the mail exchange, e.g. `mail.example.com.`. Must end with a dot, `.` declares that the name accepts no mail (RFC 7505)."),
+	},
+	render: func(record map[string]attr.Value) string {
+		return fmt.Sprintf("%d %s", int64AttributeValue(record, "priority"), stringAttributeValue(record, "exchange"))
+	},
+	parse: func(content string) (map[string]attr.Value, error) {
+		fields, err := recordFields(content, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		priority, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]attr.Value{
+			"priority": types.Int64Value(priority),
+			"exchange": types.StringValue(fields[1]),
+		}, nil
+	},
+}
+
+var srvRecordType = typedRecordType{
+	rrType:      "SRV",
+	description: "Manages the SRV records (rrset) of a name within a PowerDNS zone, with the priority, weight, port and target as separate attributes. The name is usually `_<service>._<proto>`, e.g. `_sip._udp`.",
+	attributes: map[string]schema.Attribute{
+		"priority": uint16Attribute("Priority of the target, lower values are preferred."),
+		"weight":   uint16Attribute("Relative weight of targets with the same priority."),
+		"port":     uint16Attribute("Port of the service on the target."),
+		"target":   hostnameAttribute("Hostname of the target, e.g. `sip.example.com.`. Must end with a dot, `.` declares that the service is not available."),
+	},
+	render: func(record map[string]attr.Value) string {
+		return fmt.Sprintf(
+			"%d %d %d %s",
+			int64AttributeValue(record, "priority"),
+			int64AttributeValue(record, "weight"),
+			int64AttributeValue(record, "port"),
+			stringAttributeValue(record, "target"),
+		)
+	},
+	parse: func(content string) (map[string]attr.Value, error) {
+		fields, err := recordFields(content, 4, 4)
+		if err != nil {
+			return nil, err
+		}
+		numbers, err := parseInt64Fields(fields[:3])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]attr.Value{
+			"priority": types.Int64Value(numbers[0]),
+			"weight":   types.Int64Value(numbers[1]),
+			"port":     types.Int64Value(numbers[2]),
+			"target":   types.StringValue(fields[3]),
+		}, nil
+	},
+}
+
+var caaRecordType = typedRecordType{
+	rrType:      "CAA",
+	description: "Manages the CAA records (rrset) of a name within a PowerDNS zone, with the flags, tag and value as separate attributes.",
+	attributes: map[string]schema.Attribute{
+		"flags": schema.Int64Attribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(0),
+			MarkdownDescription: "Flags of the property, `128` marks it as critical. Defaults to `0`.",
+			Validators: []validator.Int64{
+				int64validator.Between(0, 255),
+			},
+		},
+		"tag": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Tag of the property, e.g. `issue`, `issuewild` or `iodef`.",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(CAA_TAG_REGEX, "Tag must only contain letters and digits"),
+			},
+		},
+		"value": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Value of the property without quotes, e.g. `letsencrypt.org` for `issue`.",
+		},
+	},
+	render: func(record map[string]attr.Value) string {
+		return fmt.Sprintf(
+			"%d %s %s",
+			int64AttributeValue(record, "flags"),
+			stringAttributeValue(record, "tag"),
+			quoteCharacterString(stringAttributeValue(record, "value")),
+		)
+	},
+	parse: func(content string) (map[string]attr.Value, error) {
+		fields, err := recordFields(content, 3, 3)
+		if err != nil {
+			return nil, err
+		}
+		flags, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		value, err := unquote(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]attr.Value{
+			"flags": types.Int64Value(flags),
+			"tag":   types.StringValue(fields[1]),
+			"value": types.StringValue(value),
+		}, nil
+	},
+}
+
+var txtRecordType = typedRecordType{
+	rrType:      "TXT",
+	description: "Manages the TXT records (rrset) of a name within a PowerDNS zone, with the text as plain value that the provider quotes.",
+	attributes: map[string]schema.Attribute{
+		"value": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Text of the record without quotes, e.g. `v=spf1 -all`. Text longer than 255 bytes is split into several strings, which clients join again.",
+		},
+	},
+	render: func(record map[string]attr.Value) string {
+		value := stringAttributeValue(record, "value")
+
+		var chunks []string
+		for len(value) > 255 {
+			chunks = append(chunks, quoteCharacterString(value[:255]))
+			value = value[255:]
+		}
+		return strings.Join(append(chunks, quoteCharacterString(value)), " ")
+	},
+	parse: func(content string) (map[string]attr.Value, error) {
+		fields, err := recordFields(content, 1, -1)
+		if err != nil {
+			return nil, err
+		}
+		var value strings.Builder
+		for _, field := range fields {
+			text, err := unquote(field)
+			if err != nil {
+				return nil, err
+			}
+			value.WriteString(text)
+		}
+		return map[string]attr.Value{
+			"value": types.StringValue(value.String()),
+		}, nil
+	},
+}
+
+var tlsaRecordType = typedRecordType{
+	rrType:      "TLSA",
+	description: "Manages the TLSA records (rrset) of a name within a PowerDNS zone, with the fields of the certificate association as separate attributes. The name is usually `_<port>._<proto>`, e.g. `_443._tcp.www`.",
+	attributes: map[string]schema.Attribute{
+		"usage": schema.Int64Attribute{
+			Required:            true,
+			MarkdownDescription: "Certificate usage, `0` (PKIX-TA), `1` (PKIX-EE), `2` (DANE-TA) or `3` (DANE-EE).",
+			Validators: []validator.Int64{
+				int64validator.Between(0, 3),
+			},
+		},
+		"selector": schema.Int64Attribute{
+			Required:            true,
+			MarkdownDescription: "Which part of the certificate is matched, `0` for the full certificate or `1` for its public key.",
+			Validators: []validator.Int64{
+				int64validator.Between(0, 1),
+			},
+		},
+		"matching_type": schema.Int64Attribute{
+			Required:            true,
+			MarkdownDescription: "How `data` is matched, `0` for the exact data, `1` for its SHA-256 or `2` for its SHA-512 hash.",
+			Validators: []validator.Int64{
+				int64validator.Between(0, 2),
+			},
+		},
+		"data": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Certificate association data, hex encoded.",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(HEX_REGEX, "Data must be hex encoded"),
+			},
+		},
+	},
+	render: func(record map[string]attr.Value) string {
+		return fmt.Sprintf(
+			"%d %d %d %s",
+			int64AttributeValue(record, "usage"),
+			int64AttributeValue(record, "selector"),
+			int64AttributeValue(record, "matching_type"),
+			stringAttributeValue(record, "data"),
+		)
+	},
+	parse: func(content string) (map[string]attr.Value, error) {
+		fields := strings.Fields(content)
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("expected 4 fields, got %d", len(fields))
+		}
+		numbers, err := parseInt64Fields(fields[:3])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]attr.Value{
+			"usage":         types.Int64Value(numbers[0]),
+			"selector":      types.Int64Value(numbers[1]),
+			"matching_type": types.Int64Value(numbers[2]),
+			"data":          types.StringValue(strings.Join(fields[3:], "")),
+		}, nil
+	},
+}
+
+func uint16Attribute(description string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Required:            true,
+		MarkdownDescription: description,
+		Validators: []validator.Int64{
+			int64validator.Between(0, 65535),
+		},
+	}
+}
+
+func hostnameAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: description,
+		Validators: []validator.String{
+			stringvalidator.RegexMatches(regexp.MustCompile(`\.$`), "Hostname must end with a dot"),
+		},
+	}
+}
+
+func int64AttributeValue(record map[string]attr.Value, name string) int64 {
+	value, _ := record[name].(types.Int64)
+	return value.ValueInt64()
+}
+
+func stringAttributeValue(record map[string]attr.Value, name string) string {
+	value, _ := record[name].(types.String)
+	return value.ValueString()
+}
+
+func parseInt64Fields(fields []string) ([]int64, error) {
+	numbers := make([]int64, len(fields))
+	for i, field := range fields {
+		number, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		numbers[i] = number
+	}
+	return numbers, nil
+}
+
+// quoteCharacterString quotes text for the content of a record, escaping
+// quotes, backslashes and bytes that are not printable ASCII like PowerDNS.
+func quoteCharacterString(text string) string {
+	var quoted strings.Builder
+	quoted.WriteByte('"')
+	for i := 0; i < len(text); i++ {
+		switch char := text[i]; {
+		case char == '"' || char == '\\':
+			quoted.WriteByte('\\')
+			quoted.WriteByte(char)
+		case char < 0x20 || char >= 0x7f:
+			fmt.Fprintf(&quoted, "\\%03d", char)
+		default:
+			quoted.WriteByte(char)
+		}
+	}
+	quoted.WriteByte('"')
+	return quoted.String()
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTypedRecordType_roundTrip(t *testing.T) {
+	tests := map[string]struct {
+		recordType typedRecordType
+		record     map[string]attr.Value
+		content    string
+	}{
+		"MX": {
+			recordType: mxRecordType,
+			record: map[string]attr.Value{
+				"priority": types.Int64Value(10),
+				"exchange": types.StringValue("mail.example.com."),
+			},
+			content: "10 mail.example.com.",
+		},
+		"MX null": {
+			recordType: mxRecordType,
+			record: map[string]attr.Value{
+				"priority": types.Int64Value(0),
+				"exchange": types.StringValue("."),
+			},
+			content: "0 .",
+		},
+		"SRV": {
+			recordType: srvRecordType,
+			record: map[string]attr.Value{
+				"priority": types.Int64Value(10),
+				"weight":   types.Int64Value(5),
+				"port":     types.Int64Value(5060),
+				"target":   types.StringValue("sip.example.com."),
+			},
+			content: "10 5 5060 sip.example.com.",
+		},
+		"CAA": {
+			recordType: caaRecordType,
+			record: map[string]attr.Value{
+				"flags": types.Int64Value(0),
+				"tag":   types.StringValue("issue"),
+				"value": types.StringValue("letsencrypt.org"),
+			},
+			content: `0 issue "letsencrypt.org"`,
+		},
+		"CAA critical": {
+			recordType: caaRecordType,
+			record: map[string]attr.Value{
+				"flags": types.Int64Value(128),
+				"tag":   types.StringValue("iodef"),
+				"value": types.StringValue(`mailto:"ca"@example.com`),
+			},
+			content: `128 iodef "mailto:\"ca\"@example.com"`,
+		},
+		"TXT": {
+			recordType: txtRecordType,
+			record: map[string]attr.Value{
+				"value": types.StringValue("v=spf1 -all"),
+			},
+			content: `"v=spf1 -all"`,
+		},
+		"TXT with quotes and backslashes": {
+			recordType: txtRecordType,
+			record: map[string]attr.Value{
+				"value": types.StringValue(`say "hi" \o/`),
+			},
+			content: `"say \"hi\" \\o/"`,
+		},
+		"TXT non-ASCII": {
+			recordType: txtRecordType,
+			record: map[string]attr.Value{
+				"value": types.StringValue("grüße"),
+			},
+			content: `"gr\195\188\195\159e"`,
+		},
+		"TXT longer than 255 bytes": {
+			recordType: txtRecordType,
+			record: map[string]attr.Value{
+				"value": types.StringValue(strings.Repeat("a", 255) + strings.Repeat("b", 10)),
+			},
+			content: `"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("b", 10) + `"`,
+		},
+		"TLSA": {
+			recordType: tlsaRecordType,
+			record: map[string]attr.Value{
+				"usage":         types.Int64Value(3),
+				"selector":      types.Int64Value(1),
+				"matching_type": types.Int64Value(1),
+				"data":          types.StringValue("0123456789abcdef"),
+			},
+			content: "3 1 1 0123456789abcdef",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			content := test.recordType.render(test.record)
+			if content != test.content {
+				t.Errorf("render() = %q, want %q", content, test.content)
+			}
+
+			record, err := test.recordType.parse(content)
+			if err != nil {
+				t.Fatalf("parse(%q) failed: %s", content, err)
+			}
+			if len(record) != len(test.record) {
+				t.Errorf("parse(%q) = %v, want %v", content, record, test.record)
+			}
+			for name, want := range test.record {
+				if !record[name].Equal(want) {
+					t.Errorf("parse(%q)[%q] = %s, want %s", content, name, record[name], want)
+				}
+			}
+		})
+	}
+}
+
+func TestTypedRecordType_parseSplitMultiByte(t *testing.T) {
+	// A multi-byte character crossing the 255 byte boundary is split between
+	// two strings and joined again on parse.
+	value := strings.Repeat("a", 254) + "ü" + "b"
+
+	content := txtRecordType.render(map[string]attr.Value{"value": types.StringValue(value)})
+	record, err := txtRecordType.parse(content)
+	if err != nil {
+		t.Fatalf("parse(%q) failed: %s", content, err)
+	}
+	if got := stringAttributeValue(record, "value"); got != value {
+		t.Errorf("parse(render(%q)) = %q", value, got)
+	}
+}
@@ -7,21 +7,173 @@ import (
 	"time"
 )
 
-func IncreaseSOASerial(current string) (string, error) {
-	today := time.Now().Format("20060102")
+// NextSOASerial returns the serial following current for zones whose serial
+// is owned by the provider instead of PowerDNS. The strategies are:
+//   - `date`: YYYYMMDDnn, today's first serial or current+1 if that is not
+//     greater, so the 100th change of a day rolls over into the next day.
+//   - `epoch`: the current unix time, or current+1 if that is not greater.
+//   - `increment`: current+1.
+//   - `keep`: current unchanged, a new zone starts with today's first `date`
+//     serial.
+//
+// An empty current serial stands for a new zone. Serials wrap around at 2^32
+// and are compared with serial number arithmetic (RFC 1982).
+func NextSOASerial(strategy string, current string) (string, error) {
+	return nextSOASerial(strategy, current, time.Now())
+}
 
-	if !strings.HasPrefix(current, today) {
-		return today + "01", nil
+func nextSOASerial(strategy string, current string, now time.Time) (string, error) {
+	var serial uint32
+	if current != "" {
+		parsed, err := strconv.ParseUint(current, 10, 32)
+		if err != nil {
+			return "", fmt.Errorf("invalid SOA serial %q: %w", current, err)
+		}
+		serial = uint32(parsed)
 	}
 
-	serialNumberString := strings.Replace(current, today, "", 1)
-
-	serialNumber, err := strconv.Atoi(serialNumberString)
+	today, err := strconv.ParseUint(now.Format("20060102")+"01", 10, 32)
 	if err != nil {
 		return "", err
 	}
 
-	serialNumber += 1
+	if strategy == "keep" {
+		if current == "" {
+			serial = uint32(today)
+		}
+		return strconv.FormatUint(uint64(serial), 10), nil
+	}
+
+	next := serial + 1
+	switch strategy {
+	case "date":
+		if current == "" || SerialGreater(uint32(today), serial) {
+			next = uint32(today)
+		}
+	case "epoch":
+		epoch := uint32(now.Unix())
+		if current == "" || SerialGreater(epoch, serial) {
+			next = epoch
+		}
+	case "increment":
+	default:
+		return "", fmt.Errorf("unknown serial strategy %q", strategy)
+	}
+
+	if current != "" && !SerialGreater(next, serial) {
+		return "", fmt.Errorf("serial strategy %q produced %d, which is not greater than the current serial %d", strategy, next, serial)
+	}
+
+	return strconv.FormatUint(uint64(next), 10), nil
+}
+
+// SerialGreater reports whether serial a is greater than b in serial number
+// arithmetic (RFC 1982), i.e. whether secondaries at b would transfer a.
+func SerialGreater(a, b uint32) bool {
+	return a != b && a-b < 1<<31
+}
+
+// SOAContent holds the fields of an SOA record's content.
+type SOAContent struct {
+	MName   string
+	RName   string
+	Serial  int64
+	Refresh int64
+	Retry   int64
+	Expire  int64
+	Minimum int64
+}
+
+// ParseSOAContent parses SOA record content as returned by PowerDNS, e.g.
+// `ns1.example.com. hostmaster.example.com. 2024010101 10800 3600 604800 3600`.
+func ParseSOAContent(content string) (SOAContent, error) {
+	fields := strings.Fields(content)
+	if len(fields) != 7 {
+		return SOAContent{}, fmt.Errorf("expected 7 fields in SOA record, got %d: %q", len(fields), content)
+	}
+
+	numbers := make([]int64, 0, 5)
+	for _, field := range fields[2:] {
+		number, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return SOAContent{}, fmt.Errorf("failed to parse SOA number %q: %w", field, err)
+		}
+		numbers = append(numbers, number)
+	}
+
+	return SOAContent{
+		MName:   fields[0],
+		RName:   fields[1],
+		Serial:  numbers[0],
+		Refresh: numbers[1],
+		Retry:   numbers[2],
+		Expire:  numbers[3],
+		Minimum: numbers[4],
+	}, nil
+}
+
+// DSContent holds the fields of a DS record's content.
+type DSContent struct {
+	KeyTag     int64
+	Algorithm  int64
+	DigestType int64
+	Digest     string
+}
+
+// ParseDSContent parses DS record content as returned by PowerDNS, e.g.
+// `12345 13 2 3e5a...`.
+func ParseDSContent(content string) (DSContent, error) {
+	fields := strings.Fields(content)
+	if len(fields) < 4 {
+		return DSContent{}, fmt.Errorf("expected 4 fields in DS record, got %d: %q", len(fields), content)
+	}
+
+	numbers := make([]int64, 0, 3)
+	for _, field := range fields[:3] {
+		number, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return DSContent{}, fmt.Errorf("failed to parse DS number %q: %w", field, err)
+		}
+		numbers = append(numbers, number)
+	}
+
+	return DSContent{
+		KeyTag:     numbers[0],
+		Algorithm:  numbers[1],
+		DigestType: numbers[2],
+		Digest:     strings.Join(fields[3:], ""),
+	}, nil
+}
+
+// DNSKeyContent holds the fields of a DNSKEY record's content.
+type DNSKeyContent struct {
+	Flags     int64
+	Protocol  int64
+	Algorithm int64
+	PublicKey string
+}
+
+// ParseDNSKeyContent parses DNSKEY record content as returned by PowerDNS,
+// e.g. `257 3 13 mdsswUyr3DPW...`.
+func ParseDNSKeyContent(content string) (DNSKeyContent, error) {
+	fields := strings.Fields(content)
+	if len(fields) < 4 {
+		return DNSKeyContent{}, fmt.Errorf("expected 4 fields in DNSKEY record, got %d: %q", len(fields), content)
+	}
+
+	numbers := make([]int64, 0, 3)
+	for _, field := range fields[:3] {
+		number, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return DNSKeyContent{}, fmt.Errorf("failed to parse DNSKEY number %q: %w", field, err)
+		}
+		numbers = append(numbers, number)
+	}
 
-	return fmt.Sprintf("%s%02d", today, serialNumber), nil
+	return DNSKeyContent{
+		Flags:     numbers[0],
+		Protocol:  numbers[1],
+		Algorithm: numbers[2],
+		PublicKey: strings.Join(fields[3:], ""),
+	}, nil
 }
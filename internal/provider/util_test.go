@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNextSOASerial(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+	epoch := strconv.FormatInt(now.Unix(), 10)
+
+	tests := map[string]struct {
+		strategy  string
+		current   string
+		want      string
+		wantError bool
+	}{
+		"date new zone":                {strategy: "date", current: "", want: "2026101601"},
+		"date from an earlier day":     {strategy: "date", current: "2026101507", want: "2026101601"},
+		"date same day":                {strategy: "date", current: "2026101605", want: "2026101606"},
+		"date rolls over after 99":     {strategy: "date", current: "2026101699", want: "2026101700"},
+		"date ahead of today":          {strategy: "date", current: "2026101799", want: "2026101800"},
+		"date from an epoch serial":    {strategy: "date", current: "4000000000", want: "4000000001"},
+		"date from a small serial":     {strategy: "date", current: "7", want: "2026101601"},
+		"epoch new zone":               {strategy: "epoch", current: "", want: epoch},
+		"epoch from an older serial":   {strategy: "epoch", current: "1700000000", want: epoch},
+		"epoch below a date serial":    {strategy: "epoch", current: "2026101601", want: "2026101602"},
+		"epoch ahead of now":           {strategy: "epoch", current: strconv.FormatInt(now.Unix()+5, 10), want: strconv.FormatInt(now.Unix()+6, 10)},
+		"increment":                    {strategy: "increment", current: "41", want: "42"},
+		"increment new zone":           {strategy: "increment", current: "", want: "1"},
+		"increment wraps around":       {strategy: "increment", current: "4294967295", want: "0"},
+		"keep":                         {strategy: "keep", current: "2024010101", want: "2024010101"},
+		"keep new zone starts at date": {strategy: "keep", current: "", want: "2026101601"},
+		"invalid serial":               {strategy: "date", current: "abc", wantError: true},
+		"serial out of range":          {strategy: "increment", current: "4294967296", wantError: true},
+		"unknown strategy":             {strategy: "random", current: "1", wantError: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := nextSOASerial(test.strategy, test.current, now)
+			if (err != nil) != test.wantError {
+				t.Fatalf("nextSOASerial(%q, %q) error = %v, want error %t", test.strategy, test.current, err, test.wantError)
+			}
+			if got != test.want {
+				t.Errorf("nextSOASerial(%q, %q) = %q, want %q", test.strategy, test.current, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSerialGreater(t *testing.T) {
+	tests := []struct {
+		a, b uint32
+		want bool
+	}{
+		{a: 1, b: 0, want: true},
+		{a: 0, b: 1, want: false},
+		{a: 5, b: 5, want: false},
+		{a: 2026101601, b: 2026101599, want: true},
+		// After wrapping around 2^32, small serials are greater again.
+		{a: 0, b: 4294967295, want: true},
+		{a: 10, b: 4294967000, want: true},
+		{a: 4294967295, b: 0, want: false},
+		{a: 2147483647, b: 0, want: true},
+		// Serials exactly 2^31 apart are incomparable (RFC 1982, 3.2).
+		{a: 2147483648, b: 0, want: false},
+		{a: 0, b: 2147483648, want: false},
+	}
+
+	for _, test := range tests {
+		if got := SerialGreater(test.a, test.b); got != test.want {
+			t.Errorf("SerialGreater(%d, %d) = %t, want %t", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestParseDSContent(t *testing.T) {
+	tests := map[string]struct {
+		content   string
+		want      DSContent
+		wantError bool
+	}{
+		"SHA-256": {
+			content: "12345 13 2 3e5a0b",
+			want:    DSContent{KeyTag: 12345, Algorithm: 13, DigestType: 2, Digest: "3e5a0b"},
+		},
+		"digest split into several fields": {
+			content: "2371 8 4 3E5A 0B7C",
+			want:    DSContent{KeyTag: 2371, Algorithm: 8, DigestType: 4, Digest: "3E5A0B7C"},
+		},
+		"missing digest": {
+			content:   "12345 13 2",
+			wantError: true,
+		},
+		"digest type not a number": {
+			content:   "12345 13 SHA256 3e5a0b",
+			wantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseDSContent(test.content)
+			if test.wantError {
+				if err == nil {
+					t.Errorf("ParseDSContent(%q) = %+v, want error", test.content, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDSContent(%q) failed: %s", test.content, err)
+			}
+			if got != test.want {
+				t.Errorf("ParseDSContent(%q) = %+v, want %+v", test.content, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = masterAddressValidator{}
+
+// masterAddressValidator validates the address of a master as accepted by
+// PowerDNS: an IPv4 or IPv6 address with an optional port, e.g. `192.0.2.1`,
+// `192.0.2.1:5300`, `2001:db8::1` or `[2001:db8::1]:5300`.
+type masterAddressValidator struct{}
+
+func (v masterAddressValidator) Description(ctx context.Context) string {
+	return "value must be an IP address with an optional port"
+}
+
+func (v masterAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v masterAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, ok := canonicalMaster(req.ConfigValue.ValueString()); !ok {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Master Address",
+			fmt.Sprintf("Expected an IP address with an optional port like '192.0.2.1:5300' or '[2001:db8::1]:5300', got: %s", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// metadataKinds are the built-in metadata kinds PowerDNS allows to change
+// through the API.
+var metadataKinds = []string{
+	"ALLOW-AXFR-FROM",
+	"ALLOW-DNSUPDATE-FROM",
+	"ALSO-NOTIFY",
+	"AXFR-SOURCE",
+	"FORWARD-DNSUPDATE",
+	"GSS-ACCEPTOR-PRINCIPAL",
+	"GSS-ALLOW-AXFR-PRINCIPAL",
+	"IXFR",
+	"NOTIFY-DNSUPDATE",
+	"PUBLISH-CDNSKEY",
+	"PUBLISH-CDS",
+	"SLAVE-RENOTIFY",
+	"SOA-EDIT",
+	"SOA-EDIT-DNSUPDATE",
+	"TSIG-ALLOW-DNSUPDATE",
+}
+
+// readOnlyMetadataKinds are rejected by the metadata API, mapped to the
+// pdns_zone attribute managing them if there is one.
+var readOnlyMetadataKinds = map[string]string{
+	"API-RECTIFY":      "api_rectify",
+	"AXFR-MASTER-TSIG": "slave_tsig_key_ids",
+	"LUA-AXFR-SCRIPT":  "",
+	"NSEC3NARROW":      "nsec3narrow",
+	"NSEC3PARAM":       "nsec3param",
+	"PRESIGNED":        "presigned",
+	"SOA-EDIT-API":     "soa_edit_api",
+	"TSIG-ALLOW-AXFR":  "master_tsig_key_ids",
+}
+
+var _ validator.String = metadataKindValidator{}
+
+// metadataKindValidator validates a metadata kind that can be changed through
+// the API: a writable built-in kind or a custom kind starting with `X-`.
+type metadataKindValidator struct{}
+
+func (v metadataKindValidator) Description(ctx context.Context) string {
+	return "value must be a writable metadata kind or start with X-"
+}
+
+func (v metadataKindValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a writable metadata kind or start with `X-`"
+}
+
+func (v metadataKindValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	kind := req.ConfigValue.ValueString()
+	if strings.HasPrefix(kind, "X-") || slices.Contains(metadataKinds, kind) {
+		return
+	}
+
+	if attribute, readOnly := readOnlyMetadataKinds[kind]; readOnly {
+		detail := fmt.Sprintf("The metadata kind %s can not be changed through the PowerDNS API.", kind)
+		if attribute != "" {
+			detail += fmt.Sprintf(" Use the `%s` attribute of `pdns_zone` instead.", attribute)
+		}
+		resp.Diagnostics.AddAttributeError(req.Path, "Read-Only Metadata Kind", detail)
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Metadata Kind",
+		fmt.Sprintf("Expected one of %s or a custom kind starting with 'X-', got: %s", strings.Join(metadataKinds, ", "), kind),
+	)
+}
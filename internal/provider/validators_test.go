@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMasterAddressValidator(t *testing.T) {
+	tests := map[string]struct {
+		value     types.String
+		wantError bool
+	}{
+		"IPv4":                        {value: types.StringValue("192.0.2.1")},
+		"IPv4 with port":              {value: types.StringValue("192.0.2.1:5300")},
+		"IPv4 with port 53":           {value: types.StringValue("192.0.2.1:53")},
+		"IPv6":                        {value: types.StringValue("2001:db8::1")},
+		"bracketed IPv6 with port":    {value: types.StringValue("[2001:db8::1]:5300")},
+		"bracketed IPv6 without port": {value: types.StringValue("[2001:db8::1]")},
+		"port 0":                      {value: types.StringValue("192.0.2.1:0"), wantError: true},
+		"bracketed IPv6 with port 0":  {value: types.StringValue("[2001:db8::1]:0"), wantError: true},
+		"hostname":                    {value: types.StringValue("ns1.example.com"), wantError: true},
+		"null":                        {value: types.StringNull()},
+		"unknown":                     {value: types.StringUnknown()},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("masters").AtListIndex(0), ConfigValue: test.value}
+			var resp validator.StringResponse
+			masterAddressValidator{}.ValidateString(context.Background(), req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != test.wantError {
+				t.Errorf("error = %t, want %t: %v", got, test.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}